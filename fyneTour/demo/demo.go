@@ -0,0 +1,146 @@
+// Package demo is a registry of the Fyne tour demos.
+//
+// Every demo builds its window on the given app and returns it without showing it,
+// so the same demo can be run on its own or opened from the launcher window.
+package demo
+
+import (
+	"log"
+	"math/rand"
+	"sort"
+
+	"fyne.io/fyne/v2"
+
+	"goTour/clock"
+)
+
+// group a demo belongs to
+type Category string
+
+// demo categories, following the sections of the Fyne tour
+const (
+	Canvas  Category = "canvas"
+	Layout  Category = "layout"
+	Widgets Category = "widgets"
+	Binding Category = "binding"
+)
+
+// categories in tour order
+var Categories = []Category{Canvas, Layout, Widgets, Binding}
+
+// what demos run against: where they log to, where their random numbers come from
+// and the clock they wait on, so a demo looks the same on every run with the same seed
+// and tests can move time forward instead of sleeping
+type Env struct {
+	Log   *log.Logger
+	Rand  rand.Source
+	Clock clock.Clock
+}
+
+// a runnable demo
+type Demo struct {
+	Name        string
+	Category    Category
+	Description string
+	Window      func(fyne.App, Env) fyne.Window // create (but don't show) the demo window
+}
+
+// adapt a demo that only needs the app to the Demo.Window signature
+func plain(window func(fyne.App) fyne.Window) func(fyne.App, Env) fyne.Window {
+	return func(myApp fyne.App, _ Env) fyne.Window {
+		return window(myApp)
+	}
+}
+
+// adapt a demo that logs to the Demo.Window signature
+func withLog(window func(fyne.App, *log.Logger) fyne.Window) func(fyne.App, Env) fyne.Window {
+	return func(myApp fyne.App, env Env) fyne.Window {
+		return window(myApp, env.Log)
+	}
+}
+
+// adapt a demo that uses random numbers to the Demo.Window signature; sources aren't
+// safe for concurrent use, so each window gets its own, seeded from env.Rand
+func withRand(window func(fyne.App, rand.Source) fyne.Window) func(fyne.App, Env) fyne.Window {
+	return func(myApp fyne.App, env Env) fyne.Window {
+		return window(myApp, rand.NewSource(env.Rand.Int63()))
+	}
+}
+
+// adapt a demo that waits for time to pass to the Demo.Window signature
+func withClock(window func(fyne.App, clock.Clock) fyne.Window) func(fyne.App, Env) fyne.Window {
+	return func(myApp fyne.App, env Env) fyne.Window {
+		return window(myApp, env.Clock)
+	}
+}
+
+// all demos in tour order
+var demos = []Demo{
+	{"introduction", Canvas, "hello world label", plain(introduction)},
+	{"windowHandling", Canvas, "second window shown after 5 seconds", withClock(windowHandling)},
+	{"canvasObject", Canvas, "canvas content changing every 2 seconds", withClock(canvasObject)},
+	{"rectangle", Canvas, "white rectangle", plain(rectangle)},
+	{"text", Canvas, "right aligned italic text", plain(text)},
+	{"line", Canvas, "thick diagonal line", plain(line)},
+	{"circle", Canvas, "circle with a grey outline", plain(circle)},
+	{"image", Canvas, "Fyne logo image", plain(image)},
+	{"raster", Canvas, "random coloured pixels", withRand(raster)},
+	{"gradient", Canvas, "white to transparent horizontal gradient", plain(gradient)},
+	{"containerLayout", Layout, "texts placed without a layout", plain(containerLayout)},
+	{"appTabsContainer", Layout, "tabs down the leading edge", plain(appTabsContainer)},
+	{"boxContainer", Layout, "VBox that grows when the button is pressed", plain(boxContainer)},
+	{"widgets", Widgets, "single entry widget", plain(widgets)},
+	{"button", Widgets, "button that logs taps", withLog(button)},
+	{"entry", Widgets, "entry with a save button that logs the text", withLog(entry)},
+	{"choices", Widgets, "check box, radio group and select", withLog(choices)},
+	{"form", Widgets, "form with entry and multiline entry", withLog(form)},
+	{"progressBar", Widgets, "progress bar filling up and an infinite progress bar", withClock(progressBar)},
+	{"toolbar", Widgets, "toolbar above some content", withLog(toolbar)},
+	{"list", Widgets, "list of strings", plain(list)},
+	{"table", Widgets, "2x2 table", plain(table)},
+	{"sysinfo", Widgets, "runtime and build information to copy into a bug report", plain(sysinfoWindow)},
+	{"tictactoe", Widgets, "tic-tac-toe on a grid of buttons against a friend or the computer", tictactoeWindow},
+	{"dataBinding", Binding, "string and int bindings", withLog(dataBinding)},
+	{"bindingSimpleWidgets", Binding, "label bound to a string that changes after 2 seconds", withClock(bindingSimpleWidgets)},
+	{"twoWayBinding", Binding, "label and entry bound to the same string", plain(twoWayBinding)},
+	{"conversion", Binding, "slider bound to a float shown as strings", plain(conversion)},
+	{"listData", Binding, "list bound to a string list with an append button", plain(listData)},
+}
+
+// All returns every demo in tour order
+func All() []Demo {
+	all := make([]Demo, len(demos))
+	copy(all, demos)
+	return all
+}
+
+// InCategory returns the demos of the given category in tour order
+func InCategory(c Category) []Demo {
+	var found []Demo
+	for _, d := range demos {
+		if d.Category == c {
+			found = append(found, d)
+		}
+	}
+	return found
+}
+
+// Find returns the demo with the given name
+func Find(name string) (Demo, bool) {
+	for _, d := range demos {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return Demo{}, false
+}
+
+// Names returns the sorted demo names
+func Names() []string {
+	names := make([]string, len(demos))
+	for i, d := range demos {
+		names[i] = d.Name
+	}
+	sort.Strings(names)
+	return names
+}
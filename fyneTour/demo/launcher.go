@@ -0,0 +1,82 @@
+package demo
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Launcher creates a window listing every demo by category.
+// Selecting a demo shows its description and the open button opens it in a new window.
+// Opened demos run with env.
+func Launcher(myApp fyne.App, env Env) fyne.Window {
+	w := myApp.NewWindow("Fyne Tour")
+
+	title := widget.NewLabelWithStyle("Select a demo", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	description := widget.NewLabel("")
+	description.Wrapping = fyne.TextWrapWord
+
+	var selected Demo
+	open := widget.NewButton("Open", func() {
+		if selected.Window != nil {
+			selected.Window(myApp, env).Show()
+		}
+	})
+	open.Disable()
+
+	tree := widget.NewTree(
+		treeChildren,
+		func(uid widget.TreeNodeID) bool {
+			return !strings.Contains(uid, "/")
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(uid widget.TreeNodeID, branch bool, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(uid[strings.LastIndex(uid, "/")+1:])
+		})
+	tree.OnSelected = func(uid widget.TreeNodeID) {
+		if !strings.Contains(uid, "/") {
+			return // category
+		}
+		d, ok := Find(uid[strings.LastIndex(uid, "/")+1:])
+		if !ok {
+			return
+		}
+		selected = d
+		title.SetText(d.Name)
+		description.SetText(d.Description + "\n\nCategory: " + string(d.Category))
+		open.Enable()
+	}
+	tree.OpenAllBranches()
+
+	details := container.NewBorder(title, open, nil, nil, description)
+	split := container.NewHSplit(tree, details)
+	split.Offset = 0.4
+
+	w.SetContent(split)
+	w.Resize(fyne.NewSize(600, 400))
+	return w
+}
+
+// tree node IDs: "<category>" for branches and "<category>/<demo>" for leaves
+func treeChildren(uid widget.TreeNodeID) []widget.TreeNodeID {
+	if uid == "" {
+		ids := make([]widget.TreeNodeID, len(Categories))
+		for i, c := range Categories {
+			ids[i] = string(c)
+		}
+		return ids
+	}
+	if strings.Contains(uid, "/") {
+		return nil
+	}
+
+	var ids []widget.TreeNodeID
+	for _, d := range InCategory(Category(uid)) {
+		ids = append(ids, uid+"/"+d.Name)
+	}
+	return ids
+}
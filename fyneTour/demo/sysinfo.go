@@ -0,0 +1,48 @@
+package demo
+
+import (
+	"bytes"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"goTour/sysinfo"
+)
+
+// runtime and build information of the tour, as text and JSON tabs with buttons to
+// refresh the snapshot and copy it to the clipboard for a bug report
+func sysinfoWindow(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("System Information")
+
+	text := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	json := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	refresh := func() {
+		info := sysinfo.Collect()
+		var buf bytes.Buffer
+		info.WriteText(&buf)
+		text.SetText(buf.String())
+		buf.Reset()
+		info.WriteJSON(&buf)
+		json.SetText(buf.String())
+	}
+	refresh()
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Text", container.NewScroll(text)),
+		container.NewTabItem("JSON", container.NewScroll(json)),
+	)
+	// copy whichever format is showing
+	copyButton := widget.NewButton("Copy", func() {
+		if tabs.SelectedIndex() == 1 {
+			w.Clipboard().SetContent(json.Text)
+			return
+		}
+		w.Clipboard().SetContent(text.Text)
+	})
+	buttons := container.NewHBox(widget.NewButton("Refresh", refresh), copyButton)
+
+	w.SetContent(container.NewBorder(nil, buttons, nil, nil, tabs))
+	w.Resize(fyne.NewSize(640, 480))
+	return w
+}
@@ -0,0 +1,160 @@
+package demo
+
+import (
+	"math/rand"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+
+	"goTour/clock"
+	"goTour/tictactoe"
+)
+
+// game modes offered by the mode selector
+const (
+	humanVsHuman = "Human vs Human"
+	humanVsAI    = "Human vs AI"
+)
+
+// tic-tac-toe board of buttons with the game behind it; against the AI the human plays X
+// and the AI thinks in the background, so mu guards the game and the widgets showing it
+type ticTacToe struct {
+	mu       sync.Mutex
+	game     *tictactoe.Game
+	ai       *tictactoe.AI // nil for two humans
+	thinking bool          // the AI is choosing its reply
+	round    int           // counts games, so a reply to an abandoned game is dropped
+	src      rand.Source
+	clock    clock.Clock // the AI's thinking time is measured on
+	cells    [][]*widget.Button
+	status   binding.String // whose turn it is, or the result once the game is over
+	banner   *widget.Label  // shows status
+	mode     *widget.Select
+	newGame  *widget.Button
+	content  fyne.CanvasObject
+}
+
+// build the board, status label, new game button and mode selector; the AI draws its
+// random moves from src and measures its thinking time on c
+func newTicTacToe(src rand.Source, c clock.Clock) *ticTacToe {
+	t := &ticTacToe{src: src, clock: c, status: binding.NewString()}
+
+	grid := container.New(layout.NewGridLayout(tictactoe.Size))
+	t.cells = make([][]*widget.Button, tictactoe.Size)
+	for r := range t.cells {
+		t.cells[r] = make([]*widget.Button, tictactoe.Size)
+		for c := range t.cells[r] {
+			m := tictactoe.Move{Row: r, Col: c}
+			t.cells[r][c] = widget.NewButton("", func() { t.tap(m) })
+			grid.Add(t.cells[r][c])
+		}
+	}
+
+	t.banner = widget.NewLabelWithData(t.status)
+	t.banner.Alignment = fyne.TextAlignCenter
+	t.newGame = widget.NewButton("New Game", t.reset)
+	t.mode = widget.NewSelect([]string{humanVsHuman, humanVsAI}, func(string) { t.reset() })
+	t.mode.SetSelected(humanVsHuman) // starts the first game
+
+	top := container.NewHBox(t.mode, layout.NewSpacer(), t.newGame)
+	// not container.NewBorder, which measures the banner while its binding may still be
+	// setting the text; the window lays it out
+	t.content = &fyne.Container{
+		Layout:  layout.NewBorderLayout(top, t.banner, nil, nil),
+		Objects: []fyne.CanvasObject{grid, top, t.banner},
+	}
+	return t
+}
+
+// start a new game in the selected mode
+func (t *ticTacToe) reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.game = tictactoe.NewGame()
+	t.ai = nil
+	t.thinking = false
+	t.round++
+	if t.mode.Selected == humanVsAI {
+		// its own source: an abandoned AI may still be searching with the old one
+		t.ai = tictactoe.NewAI(tictactoe.Hard, rand.NewSource(t.src.Int63()))
+		t.ai.Clock = t.clock
+	}
+	t.update()
+}
+
+// play the human's move in cell m, and start the AI's reply if it is playing
+func (t *ticTacToe) tap(m tictactoe.Move) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.thinking || t.game.Play(m) != nil {
+		return // the AI's turn, a taken cell or a finished game
+	}
+	if t.ai != nil && t.game.Status() == tictactoe.InProgress {
+		t.thinking = true
+		go t.reply(t.game, t.ai, t.round)
+	}
+	t.update()
+}
+
+// choose the AI's move off the UI thread and play it, unless a new game has started;
+// nothing else changes g while the AI is thinking
+func (t *ticTacToe) reply(g *tictactoe.Game, ai *tictactoe.AI, round int) {
+	m, err := ai.Move(g)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if round != t.round {
+		return
+	}
+	if err == nil {
+		g.Play(m)
+	}
+	t.thinking = false
+	t.update()
+}
+
+// show the board and status, highlighting the winning line once the game is over; the
+// caller holds mu
+func (t *ticTacToe) update() {
+	board := t.game.Board()
+	_, line := t.game.WinningLine()
+	over := t.game.Status() != tictactoe.InProgress
+	for r := range t.cells {
+		for c, b := range t.cells[r] {
+			text := board[r][c]
+			if text == tictactoe.Empty {
+				text = ""
+			}
+			b.SetText(text)
+			b.Importance = widget.MediumImportance
+			if over || t.thinking || board[r][c] != tictactoe.Empty {
+				b.Disable()
+			} else {
+				b.Enable()
+			}
+		}
+	}
+	for _, m := range line {
+		t.cells[m.Row][m.Col].Importance = widget.HighImportance
+		t.cells[m.Row][m.Col].Refresh()
+	}
+
+	switch s := t.game.Status(); s {
+	case tictactoe.InProgress:
+		t.status.Set(t.game.Turn() + " to move")
+	default:
+		t.status.Set(s.String() + "!")
+	}
+}
+
+// tic-tac-toe for two players or against the computer, on a grid of buttons; like
+// withRand, it gives the window its own source
+func tictactoeWindow(myApp fyne.App, env Env) fyne.Window {
+	w := myApp.NewWindow("Tic-Tac-Toe")
+	w.SetContent(newTicTacToe(rand.NewSource(env.Rand.Int63()), env.Clock).content)
+	w.Resize(fyne.NewSize(320, 360))
+	return w
+}
@@ -0,0 +1,167 @@
+package demo
+
+import (
+	"math/rand"
+	"testing"
+
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"goTour/clock"
+	"goTour/tictactoe"
+)
+
+// game in the test app's window, with the texts its banner shows
+type testGame struct {
+	*ticTacToe
+	shown <-chan string
+}
+
+// set up a game in the test app's window; the AI's clock stands still, so it never runs
+// out of thinking time and always plays the same moves
+func newTestTicTacToe(t *testing.T) *testGame {
+	t.Helper()
+	test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	g := &testGame{ticTacToe: newTicTacToe(rand.NewSource(1), clock.NewFake(start))}
+	g.shown = shownText(g.status, g.banner)
+	waitForBanner(t, g, "X to move") // before the window lays out the banner
+	w := test.NewWindow(g.content)
+	t.Cleanup(w.Close)
+	return g
+}
+
+// tap the cells given as row, column pairs
+func tapCells(g *testGame, cells ...int) {
+	for i := 0; i < len(cells); i += 2 {
+		test.Tap(g.cells[cells[i]][cells[i+1]])
+	}
+}
+
+// wait for the banner to show one of want and return it. The AI replies in the
+// background and the banner's binding updates it in the background too; texts the
+// status has already moved on from are skipped. The status is set last, so the board
+// and game can be read once the banner shows it.
+func waitForBanner(t *testing.T, g *testGame, want ...string) string {
+	t.Helper()
+	for {
+		text := nextText(t, g.shown)
+		if s, _ := g.status.Get(); s != text {
+			continue
+		}
+		for _, w := range want {
+			if text == w {
+				flushBindings()
+				return text
+			}
+		}
+	}
+}
+
+// wait for the bindings to deliver every change made so far, so the banner can be read:
+// they share one queue, and a new listener is first called after those already waiting
+func flushBindings() {
+	done := make(chan struct{})
+	binding.NewString().AddListener(binding.NewDataListener(func() { close(done) }))
+	<-done
+}
+
+func TestTicTacToeWin(t *testing.T) {
+	g := newTestTicTacToe(t)
+
+	// X takes the top row while O plays in the middle row
+	tapCells(g, 0, 0, 1, 0, 0, 1, 1, 1, 0, 2)
+	waitForBanner(t, g, "X wins!")
+
+	for c, b := range g.cells[0] {
+		if b.Text != tictactoe.X || b.Importance != widget.HighImportance {
+			t.Errorf("cell 0 %d = %q with importance %v, want highlighted X", c, b.Text, b.Importance)
+		}
+	}
+	if b := g.cells[2][2]; !b.Disabled() {
+		t.Error("empty cell is still enabled after the game is over")
+	}
+	// taps after the win change nothing
+	tapCells(g, 2, 2)
+	flushBindings()
+	if g.banner.Text != "X wins!" || g.cells[2][2].Text != "" {
+		t.Errorf("after tapping a finished game: banner %q, cell %q", g.banner.Text, g.cells[2][2].Text)
+	}
+}
+
+func TestTicTacToeOWinsAndDraw(t *testing.T) {
+	g := newTestTicTacToe(t)
+	tapCells(g, 0, 0, 1, 1, 0, 1, 0, 2, 2, 2, 2, 0)
+	waitForBanner(t, g, "O wins!")
+
+	test.Tap(g.newGame)
+	waitForBanner(t, g, "X to move")
+	tapCells(g, 0, 0, 0, 1, 0, 2, 1, 1, 1, 0, 1, 2, 2, 1, 2, 0, 2, 2)
+	waitForBanner(t, g, "draw!")
+}
+
+func TestTicTacToeOccupiedCell(t *testing.T) {
+	g := newTestTicTacToe(t)
+	tapCells(g, 1, 1, 1, 1)
+	waitForBanner(t, g, "O to move")
+	if got := g.cells[1][1].Text; got != tictactoe.X {
+		t.Errorf("centre = %q after tapping it twice, want X", got)
+	}
+}
+
+func TestTicTacToeNewGame(t *testing.T) {
+	g := newTestTicTacToe(t)
+	tapCells(g, 0, 0, 1, 1)
+	test.Tap(g.newGame)
+	waitForBanner(t, g, "X to move")
+	for r := range g.cells {
+		for c, b := range g.cells[r] {
+			if b.Text != "" || b.Disabled() {
+				t.Errorf("cell %d %d = %q (disabled %v) after a new game, want empty and enabled", r, c, b.Text, b.Disabled())
+			}
+		}
+	}
+}
+
+func TestTicTacToeAgainstAI(t *testing.T) {
+	g := newTestTicTacToe(t)
+	g.mode.SetSelected(humanVsAI)
+	if g.ai == nil {
+		t.Fatal("no AI after selecting", humanVsAI)
+	}
+
+	// the AI answers every move, so it is X's turn again once it has
+	test.Tap(g.cells[0][0])
+	waitForBanner(t, g, "X to move")
+	if n := g.game.Board().Count(tictactoe.O); n != 1 {
+		t.Fatalf("AI played %d moves after the first tap, want 1", n)
+	}
+
+	// keep taking the first free cell; the AI doesn't lose to that
+	for s := "X to move"; s == "X to move"; {
+		m := g.game.Legal()[0]
+		test.Tap(g.cells[m.Row][m.Col])
+		s = waitForBanner(t, g, "X to move", "X wins!", "O wins!", "draw!")
+	}
+	if s := g.game.Status(); s != tictactoe.OWins && s != tictactoe.Draw {
+		t.Errorf("game against the AI ended with %v", s)
+	}
+
+	// a reply to an abandoned game is dropped
+	test.Tap(g.newGame)
+	waitForBanner(t, g, "X to move")
+	g.reply(tictactoe.NewGame(), g.ai, g.round-1)
+	flushBindings()
+	if g.banner.Text != "X to move" || g.game.Board().Count(tictactoe.O) != 0 {
+		t.Errorf("reply to an old game changed the new one: banner %q, position %s", g.banner.Text, g.game.Encode())
+	}
+
+	// back to two players: taps only place one mark
+	g.mode.SetSelected(humanVsHuman)
+	tapCells(g, 1, 1)
+	if g.ai != nil || g.game.Board().Count(tictactoe.O) != 0 {
+		t.Error("AI still playing after selecting", humanVsHuman)
+	}
+	waitForBanner(t, g, "O to move")
+}
@@ -0,0 +1,493 @@
+package demo
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"math/rand"
+	"time"
+
+	"fyne.io/fyne/theme"
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+
+	"goTour/clock"
+)
+
+func introduction(app fyne.App) fyne.Window {
+
+	w := app.NewWindow("Hello")                  // window name
+	w.SetContent(widget.NewLabel("Hello Fyne!")) // text display
+
+	return w
+}
+
+func windowHandling(myApp fyne.App, c clock.Clock) fyne.Window {
+	myWindow := myApp.NewWindow("Hello")
+	myWindow.SetContent(widget.NewLabel("Hello"))
+
+	go showAnother(myApp, c)
+	return myWindow
+}
+
+func showAnother(a fyne.App, c clock.Clock) {
+	c.Sleep(time.Second * 5)
+
+	win := a.NewWindow("Shown later")
+	win.SetContent(widget.NewLabel("5 seconds later"))
+	win.Resize(fyne.NewSize(200, 200))
+	win.Show()
+
+	c.Sleep(time.Second * 2)
+	win.Close()
+}
+
+func canvasObject(myApp fyne.App, c clock.Clock) fyne.Window {
+	myWindow := myApp.NewWindow("Canvas")
+	myCanvas := myWindow.Canvas()
+
+	// green text display
+	green := color.NRGBA{R: 0, G: 180, B: 0, A: 255}
+	text := canvas.NewText("Text", green)
+	text.TextStyle.Bold = true
+	myCanvas.SetContent(text)
+	go changeContent(myCanvas, c)
+
+	myWindow.Resize(fyne.NewSize(100, 100))
+	return myWindow
+}
+
+func changeContent(c fyne.Canvas, clk clock.Clock) {
+	// blue screen
+	clk.Sleep(time.Second * 2)
+	blue := color.NRGBA{R: 0, G: 0, B: 180, A: 255}
+	c.SetContent(canvas.NewRectangle(blue))
+
+	// grey line
+	clk.Sleep(time.Second * 2)
+	c.SetContent(canvas.NewLine(color.Gray{Y: 180}))
+
+	// draw circle
+	clk.Sleep(time.Second * 2)
+	red := color.NRGBA{R: 0xff, G: 0x33, B: 0x33, A: 0xff}
+	circle := canvas.NewCircle(color.White)
+	circle.StrokeWidth = 4
+	circle.StrokeColor = red
+	c.SetContent(circle)
+
+	// display image
+	clk.Sleep(time.Second * 2)
+	c.SetContent(canvas.NewImageFromResource(theme.FyneLogo()))
+}
+
+func containerLayout(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("Container")
+	green := color.NRGBA{R: 0, G: 180, B: 0, A: 255}
+
+	text1 := canvas.NewText("Hello", green)
+	text2 := canvas.NewText("There", green)
+	text2.Move(fyne.NewPos(20, 20)) // move text2 to a different position
+	content := container.NewWithoutLayout(text1, text2)
+	// content := container.New(layout.NewGridLayout(2), text1, text2)
+
+	myWindow.SetContent(content)
+	return myWindow
+}
+
+func widgets(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("Widget")
+
+	myWindow.SetContent(widget.NewEntry()) // grey box
+	return myWindow
+}
+
+func rectangle(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Rectangle")
+
+	rect := canvas.NewRectangle(color.White) // display block with white colour
+	w.SetContent(rect)
+
+	w.Resize(fyne.NewSize(150, 100))
+	return w
+}
+
+func text(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Text")
+
+	text := canvas.NewText("Text Object", color.White) // white text
+	text.Alignment = fyne.TextAlignTrailing            // right
+	text.TextStyle = fyne.TextStyle{Italic: true}      // italic
+	w.SetContent(text)
+
+	return w
+}
+
+func line(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Line")
+
+	line := canvas.NewLine(color.White)
+	line.StrokeWidth = 5 // line width
+	w.SetContent(line)
+
+	w.Resize(fyne.NewSize(100, 100)) // line from left top corner to right bottom corner
+	return w
+}
+
+func circle(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Circle")
+
+	circle := canvas.NewCircle(color.White)
+	circle.StrokeColor = color.Gray{0x99}
+	circle.StrokeWidth = 5
+	w.SetContent(circle)
+
+	w.Resize(fyne.NewSize(100, 100)) // fill window with a circle
+	return w
+}
+
+func image(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Image")
+
+	image := canvas.NewImageFromResource(theme.FyneLogo())
+	// image := canvas.NewImageFromURI(uri)
+	// image := canvas.NewImageFromImage(src)
+	// image := canvas.NewImageFromReader(reader, name)
+	// image := canvas.NewImageFromFile(fileName)
+	image.FillMode = canvas.ImageFillOriginal
+	w.SetContent(image)
+
+	return w
+}
+
+// pixels on screen, coloured from their position and a seed drawn once from src: repaints
+// run on the render goroutine, so they mustn't draw from src, and show the same picture
+func raster(myApp fyne.App, src rand.Source) fyne.Window {
+	w := myApp.NewWindow("Raster")
+
+	seed := uint64(src.Int63())
+	raster := canvas.NewRasterWithPixels(
+		func(x, y, _, _ int) color.Color {
+			return pixelColor(seed, x, y)
+		})
+	// raster := canvas.NewRasterFromImage()
+	w.SetContent(raster)
+	w.Resize(fyne.NewSize(120, 100))
+	return w
+}
+
+// random looking opaque colour of pixel x, y: the bits of a splitmix64 hash of the seed
+// and position
+func pixelColor(seed uint64, x, y int) color.Color {
+	z := seed ^ (uint64(uint32(x))<<32 | uint64(uint32(y)))
+	z += 0x9e3779b97f4a7c15
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return color.RGBA{uint8(z), uint8(z >> 8), uint8(z >> 16), 0xff}
+}
+
+// white to black gradient from left to right
+func gradient(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Gradient")
+
+	gradient := canvas.NewHorizontalGradient(color.White, color.Transparent)
+	//gradient := canvas.NewRadialGradient(color.White, color.Transparent)
+	w.SetContent(gradient)
+
+	w.Resize(fyne.NewSize(100, 100))
+	return w
+}
+
+// app with left navigation bar with 2 pages
+func appTabsContainer(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("TabContainer Widget")
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Tab 1", widget.NewLabel("Hello")),
+		container.NewTabItem("Tab 2", widget.NewLabel("World!")),
+	)
+
+	//tabs.Append(container.NewTabItemWithIcon("Home", theme.HomeIcon(), widget.NewLabel("Home tab")))
+
+	tabs.SetTabLocation(container.TabLocationLeading)
+
+	myWindow.SetContent(tabs)
+	return myWindow
+}
+
+// box container adding static element to page when button pressed
+func boxContainer(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("Entry Widget")
+
+	content := container.NewVBox(
+		widget.NewLabel("The top row of the VBox"),
+		container.NewHBox(
+			widget.NewLabel("Label 1"),
+			widget.NewLabel("Label 2"),
+		),
+	)
+
+	content.Add(widget.NewButton("Add more items", func() {
+		content.Add(widget.NewLabel("Added"))
+	}))
+
+	myWindow.SetContent(content)
+	return myWindow
+}
+
+// clickable button that logs that it has been clicked
+func button(myApp fyne.App, logger *log.Logger) fyne.Window {
+	myWindow := myApp.NewWindow("Button Widget")
+
+	content := widget.NewButton("click me", func() {
+		logger.Println("tapped")
+	})
+
+	//content := widget.NewButtonWithIcon("Home", theme.HomeIcon(), func() {
+	//	logger.Println("tapped home")
+	//})
+
+	myWindow.SetContent(content)
+	return myWindow
+}
+
+// textbox entry spot with a save button. Input logged to terminal
+func entry(myApp fyne.App, logger *log.Logger) fyne.Window {
+	myWindow := myApp.NewWindow("Entry Widget")
+
+	input := widget.NewEntry()
+	input.SetPlaceHolder("Enter text...")
+
+	content := container.NewVBox(input, widget.NewButton("Save", func() {
+		logger.Println("Content was:", input.Text)
+	}))
+
+	myWindow.SetContent(content)
+	return myWindow
+}
+
+// check box, radio button, dropdown box
+func choices(myApp fyne.App, logger *log.Logger) fyne.Window {
+	myWindow := myApp.NewWindow("Choice Widgets")
+
+	check := widget.NewCheck("Optional", func(value bool) {
+		logger.Println("Check set to", value)
+	})
+	radio := widget.NewRadioGroup([]string{"Option 1", "Option 2"}, func(value string) {
+		logger.Println("Radio set to", value)
+	})
+	combo := widget.NewSelect([]string{"Option 1", "Option 2"}, func(value string) {
+		logger.Println("Select set to", value)
+	})
+
+	myWindow.SetContent(container.NewVBox(check, radio, combo))
+	return myWindow
+}
+
+// popup box with title and text boxes with a submit button
+func form(myApp fyne.App, logger *log.Logger) fyne.Window {
+	myWindow := myApp.NewWindow("Form Widget")
+
+	entry := widget.NewEntry()
+	textArea := widget.NewMultiLineEntry()
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{ // we can specify items in the constructor
+			{Text: "Entry", Widget: entry}},
+		OnSubmit: func() { // optional, handle form submission
+			logger.Println("Form submitted:", entry.Text)
+			logger.Println("multiline:", textArea.Text)
+			myWindow.Close()
+		},
+	}
+
+	// we can also append items
+	form.Append("Text", textArea)
+
+	myWindow.SetContent(form)
+	return myWindow
+}
+
+func progressBar(myApp fyne.App, c clock.Clock) fyne.Window {
+	myWindow := myApp.NewWindow("ProgressBar Widget")
+
+	progress := widget.NewProgressBar()
+	infinite := widget.NewProgressBarInfinite()
+
+	go func() {
+		for i := 0.0; i <= 1.0; i += 0.1 {
+			c.Sleep(time.Millisecond * 250)
+			progress.SetValue(i)
+		}
+	}()
+
+	myWindow.SetContent(container.NewVBox(progress, infinite))
+	return myWindow
+}
+
+// page with multiple buttons at the top with content below, tracking mouse movements
+func toolbar(myApp fyne.App, logger *log.Logger) fyne.Window {
+	myWindow := myApp.NewWindow("Toolbar Widget")
+
+	toolbar := widget.NewToolbar(
+		widget.NewToolbarAction(theme.DocumentCreateIcon(), func() {
+			logger.Println("New document")
+		}),
+		widget.NewToolbarSeparator(),
+		widget.NewToolbarAction(theme.ContentCutIcon(), func() {}),
+		widget.NewToolbarAction(theme.ContentCopyIcon(), func() {}),
+		widget.NewToolbarAction(theme.ContentPasteIcon(), func() {}),
+		widget.NewToolbarSpacer(),
+		widget.NewToolbarAction(theme.HelpIcon(), func() {
+			logger.Println("Display help")
+		}),
+	)
+
+	content := container.NewBorder(toolbar, nil, nil, nil, widget.NewLabel("Content"))
+	myWindow.SetContent(content)
+	return myWindow
+}
+
+var dataList = []string{"a", "string", "list"}
+
+// list of items (clickable but does not do anything)
+func list(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("List Widget")
+
+	list := widget.NewList(
+		func() int {
+			return len(dataList)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(i widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(dataList[i])
+		})
+
+	myWindow.SetContent(list)
+	return myWindow
+}
+
+var dataTable = [][]string{[]string{"top left", "top right"},
+	[]string{"bottom left", "bottom right"}}
+
+// table with 2 columns and rows
+func table(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("Table Widget")
+
+	list := widget.NewTable(
+		func() (int, int) {
+			return len(dataTable), len(dataTable[0])
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("wide content")
+		},
+		func(i widget.TableCellID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(dataTable[i.Row][i.Col])
+		})
+
+	myWindow.SetContent(list)
+	return myWindow
+}
+
+// bound values logged to terminal and shown in labels
+func dataBinding(myApp fyne.App, logger *log.Logger) fyne.Window {
+	w := myApp.NewWindow("Data Binding")
+
+	boundString := binding.NewString()
+	s, _ := boundString.Get()
+	logger.Printf("Bound = '%s'", s)
+
+	myInt := 5
+	boundInt := binding.BindInt(&myInt)
+	i, _ := boundInt.Get()
+	logger.Printf("Source = %d, bound = %d", myInt, i)
+
+	w.SetContent(container.NewVBox(
+		widget.NewLabelWithData(boundString),
+		widget.NewLabelWithData(binding.IntToString(boundInt)),
+	))
+	return w
+}
+
+// change from initial value to another value when increase widget size
+func bindingSimpleWidgets(myApp fyne.App, c clock.Clock) fyne.Window {
+	w := myApp.NewWindow("Simple")
+
+	str := binding.NewString()
+	str.Set("Initial value")
+
+	text := widget.NewLabelWithData(str)
+	w.SetContent(text)
+
+	go changeLater(str, c)
+
+	return w
+}
+
+// change str to a new string after 2 seconds
+func changeLater(str binding.String, c clock.Clock) {
+	c.Sleep(time.Second * 2)
+	str.Set("A new string")
+}
+
+// editible list, where if you edit one element the other element also gets edited
+func twoWayBinding(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Two Way")
+
+	str := binding.NewString()
+	str.Set("Hi!")
+
+	w.SetContent(container.NewVBox(
+		widget.NewLabelWithData(str),
+		widget.NewEntryWithData(str),
+	))
+
+	return w
+}
+
+// slider/scroller bar which converts percentage to decimal in real time
+func conversion(myApp fyne.App) fyne.Window {
+	w := myApp.NewWindow("Conversion")
+
+	f := binding.NewFloat()
+	str := binding.FloatToString(f)
+	short := binding.FloatToStringWithFormat(f, "%0.0f%%")
+	f.Set(25.0)
+
+	w.SetContent(container.NewVBox(
+		widget.NewSliderWithData(0, 100.0, f),
+		widget.NewLabelWithData(str),
+		widget.NewLabelWithData(short),
+	))
+
+	return w
+}
+
+// list of items which appends another item upon clicking a button
+func listData(myApp fyne.App) fyne.Window {
+	myWindow := myApp.NewWindow("List Data")
+
+	data := binding.BindStringList(
+		&[]string{"Item 1", "Item 2", "Item 3"},
+	)
+
+	list := widget.NewListWithData(data,
+		func() fyne.CanvasObject {
+			return widget.NewLabel("template")
+		},
+		func(i binding.DataItem, o fyne.CanvasObject) {
+			o.(*widget.Label).Bind(i.(binding.String))
+		})
+
+	add := widget.NewButton("Append", func() {
+		val := fmt.Sprintf("Item %d", data.Length()+1)
+		data.Append(val)
+	})
+	myWindow.SetContent(container.NewBorder(nil, add, nil, nil, list))
+	return myWindow
+}
@@ -0,0 +1,158 @@
+package demo
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+
+	"goTour/clock"
+)
+
+// time the fake clocks start at
+var start = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+// texts l shows as str changes. Fyne runs binding listeners one after another on a
+// single goroutine, so this listener, added after l's own, reads l's text once l has
+// taken it.
+func shownText(str binding.String, l *widget.Label) <-chan string {
+	shown := make(chan string, 100)
+	str.AddListener(binding.NewDataListener(func() { shown <- l.Text }))
+	return shown
+}
+
+// wait for the next text on shown
+func nextText(t *testing.T, shown <-chan string) string {
+	t.Helper()
+	select {
+	case s := <-shown:
+		return s
+	case <-time.After(5 * time.Second):
+		t.Fatal("label not updated")
+		return ""
+	}
+}
+
+func TestRasterSnapshot(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	w := raster(a, rand.NewSource(42))
+	t.Cleanup(w.Close)
+
+	test.AssertImageMatches(t, "raster.png", w.Canvas().Capture())
+	// a repaint draws the same pixels
+	w.Canvas().Refresh(w.Content())
+	test.AssertImageMatches(t, "raster.png", w.Canvas().Capture())
+}
+
+func TestPixelColor(t *testing.T) {
+	if a, b := pixelColor(1, 3, 4), pixelColor(1, 3, 4); a != b {
+		t.Errorf("pixelColor(1, 3, 4) = %v then %v", a, b)
+	}
+	// neighbours and other seeds get other colours
+	for _, c := range [][3]int{{1, 4, 4}, {1, 3, 5}, {1, 4, 3}, {2, 3, 4}} {
+		if pixelColor(uint64(c[0]), c[1], c[2]) == pixelColor(1, 3, 4) {
+			t.Errorf("pixelColor(%d, %d, %d) = pixelColor(1, 3, 4)", c[0], c[1], c[2])
+		}
+	}
+}
+
+func TestShowAnother(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	c := clock.NewFake(start)
+	done := make(chan struct{})
+	go func() {
+		showAnother(a, c)
+		close(done)
+	}()
+
+	c.BlockUntil(1)
+	before := len(a.Driver().AllWindows())
+	c.Advance(5 * time.Second)
+	c.BlockUntil(1) // shown, waiting to close it
+	windows := a.Driver().AllWindows()
+	if len(windows) != before+1 {
+		t.Fatalf("%d windows after 5 seconds, want %d", len(windows), before+1)
+	}
+	if l, ok := windows[before].Content().(*widget.Label); !ok || l.Text != "5 seconds later" {
+		t.Errorf("window shows %#v", windows[before].Content())
+	}
+	c.Advance(2 * time.Second)
+	<-done
+	if n := len(a.Driver().AllWindows()); n != before {
+		t.Errorf("%d windows open after 7 seconds, want %d", n, before)
+	}
+}
+
+func TestChangeContent(t *testing.T) {
+	test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	c := clock.NewFake(start)
+	cv := test.NewCanvas()
+	done := make(chan struct{})
+	go func() {
+		changeContent(cv, c)
+		close(done)
+	}()
+
+	// a new shape every 2 seconds
+	for i, want := range []string{"*canvas.Rectangle", "*canvas.Line", "*canvas.Circle", "*canvas.Image"} {
+		c.BlockUntil(1)
+		c.Advance(2 * time.Second)
+		if i < 3 {
+			c.BlockUntil(1) // waiting for the next change
+		} else {
+			<-done
+		}
+		if got := fmt.Sprintf("%T", cv.Content()); got != want {
+			t.Errorf("after %d seconds the canvas shows a %s, want a %s", 2*(i+1), got, want)
+		}
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	a := test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	c := clock.NewFake(start)
+	w := progressBar(a, c)
+	t.Cleanup(w.Close)
+	progress := w.Content().(*fyne.Container).Objects[0].(*widget.ProgressBar)
+
+	// a tenth more every 250ms; the last step, to 1, ends the goroutine and isn't checked
+	want := 0.0
+	for i := 1; i <= 10; i++ {
+		c.BlockUntil(1)
+		c.Advance(250 * time.Millisecond)
+		c.BlockUntil(1) // set, waiting for the next step
+		if progress.Value != want {
+			t.Errorf("after %v the progress is %v, want %v", time.Duration(i)*250*time.Millisecond, progress.Value, want)
+		}
+		want += 0.1
+	}
+}
+
+func TestChangeLater(t *testing.T) {
+	test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+	c := clock.NewFake(start)
+	str := binding.NewString()
+	str.Set("Initial value")
+	label := widget.NewLabelWithData(str)
+	shown := shownText(str, label)
+	go changeLater(str, c)
+
+	if got := nextText(t, shown); got != "Initial value" {
+		t.Errorf("label shows %q at first", got)
+	}
+	c.BlockUntil(1)
+	c.Advance(2 * time.Second)
+	if got := nextText(t, shown); got != "A new string" {
+		t.Errorf("label shows %q after 2 seconds", got)
+	}
+}
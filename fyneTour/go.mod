@@ -1,10 +1,11 @@
 module fyneTour
 
-go 1.17
+go 1.23
 
 require (
 	fyne.io/fyne v1.4.3
 	fyne.io/fyne/v2 v2.1.0
+	goTour v0.0.0
 )
 
 require (
@@ -26,3 +27,6 @@ require (
 	golang.org/x/text v0.3.3 // indirect
 	gopkg.in/yaml.v2 v2.2.8 // indirect
 )
+
+// the Go tour module lives next to this one
+replace goTour => ../goTour
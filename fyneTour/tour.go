@@ -1,525 +1,61 @@
+// Fyne tour launcher: run a single demo by name or pick one from the launcher window.
+// Demos with random content (raster) use a source seeded with -seed, so
+// go run . -demo raster -seed 42 draws the same pixels every time.
 package main
 
 import (
+	"flag"
 	"fmt"
-	"image/color"
 	"log"
 	"math/rand"
+	"os"
+	"text/tabwriter"
 	"time"
 
-	"fyne.io/fyne/theme"
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
-	"fyne.io/fyne/v2/container"
-	"fyne.io/fyne/v2/data/binding"
-	"fyne.io/fyne/v2/widget"
-)
-
-func introduction() {
-	app := app.New()
-
-	w := app.NewWindow("Hello")                  // window name
-	w.SetContent(widget.NewLabel("Hello Fyne!")) // text display
-
-	w.ShowAndRun()
-}
-
-func windowHandling() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Hello")
-	myWindow.SetContent(widget.NewLabel("Hello"))
-
-	go showAnother(myApp)
-	myWindow.ShowAndRun()
-}
-
-func showAnother(a fyne.App) {
-	time.Sleep(time.Second * 5)
-
-	win := a.NewWindow("Shown later")
-	win.SetContent(widget.NewLabel("5 seconds later"))
-	win.Resize(fyne.NewSize(200, 200))
-	win.Show()
-
-	time.Sleep(time.Second * 2)
-	win.Close()
-}
-
-func canvasObject() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Canvas")
-	myCanvas := myWindow.Canvas()
-
-	// green text display
-	green := color.NRGBA{R: 0, G: 180, B: 0, A: 255}
-	text := canvas.NewText("Text", green)
-	text.TextStyle.Bold = true
-	myCanvas.SetContent(text)
-	go changeContent(myCanvas)
-
-	myWindow.Resize(fyne.NewSize(100, 100))
-	myWindow.ShowAndRun()
-}
-
-func changeContent(c fyne.Canvas) {
-	// blue screen
-	time.Sleep(time.Second * 2)
-	blue := color.NRGBA{R: 0, G: 0, B: 180, A: 255}
-	c.SetContent(canvas.NewRectangle(blue))
-
-	// grey line
-	time.Sleep(time.Second * 2)
-	c.SetContent(canvas.NewLine(color.Gray{Y: 180}))
-
-	// draw circle
-	time.Sleep(time.Second * 2)
-	red := color.NRGBA{R: 0xff, G: 0x33, B: 0x33, A: 0xff}
-	circle := canvas.NewCircle(color.White)
-	circle.StrokeWidth = 4
-	circle.StrokeColor = red
-	c.SetContent(circle)
-
-	// display image
-	time.Sleep(time.Second * 2)
-	c.SetContent(canvas.NewImageFromResource(theme.FyneLogo()))
-}
-
-func containerLayout() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Container")
-	green := color.NRGBA{R: 0, G: 180, B: 0, A: 255}
-
-	text1 := canvas.NewText("Hello", green)
-	text2 := canvas.NewText("There", green)
-	text2.Move(fyne.NewPos(20, 20)) // move text2 to a different position
-	content := container.NewWithoutLayout(text1, text2)
-	// content := container.New(layout.NewGridLayout(2), text1, text2)
-
-	myWindow.SetContent(content)
-	myWindow.ShowAndRun()
-}
-
-func widgets() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Widget")
-
-	myWindow.SetContent(widget.NewEntry()) // grey box
-	myWindow.ShowAndRun()
-}
-
-func rectangle() {
-	myApp := app.New()
-	w := myApp.NewWindow("Rectangle")
-
-	rect := canvas.NewRectangle(color.White) // display block with white colour
-	w.SetContent(rect)
-
-	w.Resize(fyne.NewSize(150, 100))
-	w.ShowAndRun()
-}
-
-func text() {
-	myApp := app.New()
-	w := myApp.NewWindow("Text")
-
-	text := canvas.NewText("Text Object", color.White) // white text
-	text.Alignment = fyne.TextAlignTrailing            // right
-	text.TextStyle = fyne.TextStyle{Italic: true}      // italic
-	w.SetContent(text)
-
-	w.ShowAndRun()
-}
-
-func line() {
-	myApp := app.New()
-	w := myApp.NewWindow("Line")
-
-	line := canvas.NewLine(color.White)
-	line.StrokeWidth = 5 // line width
-	w.SetContent(line)
-
-	w.Resize(fyne.NewSize(100, 100)) // line from left top corner to right bottom corner
-	w.ShowAndRun()
-}
-
-func circle() {
-	myApp := app.New()
-	w := myApp.NewWindow("Circle")
-
-	circle := canvas.NewCircle(color.White)
-	circle.StrokeColor = color.Gray{0x99}
-	circle.StrokeWidth = 5
-	w.SetContent(circle)
-
-	w.Resize(fyne.NewSize(100, 100)) // fill window with a circle
-	w.ShowAndRun()
-}
-
-func image() {
-	myApp := app.New()
-	w := myApp.NewWindow("Image")
-
-	image := canvas.NewImageFromResource(theme.FyneLogo())
-	// image := canvas.NewImageFromURI(uri)
-	// image := canvas.NewImageFromImage(src)
-	// image := canvas.NewImageFromReader(reader, name)
-	// image := canvas.NewImageFromFile(fileName)
-	image.FillMode = canvas.ImageFillOriginal
-	w.SetContent(image)
-
-	w.ShowAndRun()
-}
-
-// pixels on screen
-func raster() {
-	myApp := app.New()
-	w := myApp.NewWindow("Raster")
-
-	raster := canvas.NewRasterWithPixels(
-		func(_, _, w, h int) color.Color {
-			return color.RGBA{uint8(rand.Intn(255)),
-				uint8(rand.Intn(255)),
-				uint8(rand.Intn(255)), 0xff}
-		})
-	// raster := canvas.NewRasterFromImage()
-	w.SetContent(raster)
-	w.Resize(fyne.NewSize(120, 100))
-	w.ShowAndRun()
-}
-
-// white to black gradient from left to right
-func gradient() {
-	myApp := app.New()
-	w := myApp.NewWindow("Gradient")
-
-	gradient := canvas.NewHorizontalGradient(color.White, color.Transparent)
-	//gradient := canvas.NewRadialGradient(color.White, color.Transparent)
-	w.SetContent(gradient)
-
-	w.Resize(fyne.NewSize(100, 100))
-	w.ShowAndRun()
-}
-
-// app with left navigation bar with 2 pages
-func appTabsContainer() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("TabContainer Widget")
-
-	tabs := container.NewAppTabs(
-		container.NewTabItem("Tab 1", widget.NewLabel("Hello")),
-		container.NewTabItem("Tab 2", widget.NewLabel("World!")),
-	)
-
-	//tabs.Append(container.NewTabItemWithIcon("Home", theme.HomeIcon(), widget.NewLabel("Home tab")))
-
-	tabs.SetTabLocation(container.TabLocationLeading)
-
-	myWindow.SetContent(tabs)
-	myWindow.ShowAndRun()
-}
-
-// box container adding static element to page when button pressed
-func boxContainer() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Entry Widget")
-
-	content := container.NewVBox(
-		widget.NewLabel("The top row of the VBox"),
-		container.NewHBox(
-			widget.NewLabel("Label 1"),
-			widget.NewLabel("Label 2"),
-		),
-	)
-
-	content.Add(widget.NewButton("Add more items", func() {
-		content.Add(widget.NewLabel("Added"))
-	}))
 
-	myWindow.SetContent(content)
-	myWindow.ShowAndRun()
-}
-
-// clickable button that logs that it has been clicked
-func button() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Button Widget")
-
-	content := widget.NewButton("click me", func() {
-		log.Println("tapped")
-	})
-
-	//content := widget.NewButtonWithIcon("Home", theme.HomeIcon(), func() {
-	//	log.Println("tapped home")
-	//})
-
-	myWindow.SetContent(content)
-	myWindow.ShowAndRun()
-}
-
-// textbox entry spot with a save button. Input logged to terminal
-func entry() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Entry Widget")
-
-	input := widget.NewEntry()
-	input.SetPlaceHolder("Enter text...")
-
-	content := container.NewVBox(input, widget.NewButton("Save", func() {
-		log.Println("Content was:", input.Text)
-	}))
-
-	myWindow.SetContent(content)
-	myWindow.ShowAndRun()
-}
-
-// check box, radio button, dropdown box
-func choices() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Choice Widgets")
-
-	check := widget.NewCheck("Optional", func(value bool) {
-		log.Println("Check set to", value)
-	})
-	radio := widget.NewRadioGroup([]string{"Option 1", "Option 2"}, func(value string) {
-		log.Println("Radio set to", value)
-	})
-	combo := widget.NewSelect([]string{"Option 1", "Option 2"}, func(value string) {
-		log.Println("Select set to", value)
-	})
-
-	myWindow.SetContent(container.NewVBox(check, radio, combo))
-	myWindow.ShowAndRun()
-}
-
-// popup box with title and text boxes with a submit button
-func form() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Form Widget")
-
-	entry := widget.NewEntry()
-	textArea := widget.NewMultiLineEntry()
+	"fyneTour/demo"
+	"goTour/clock"
+)
 
-	form := &widget.Form{
-		Items: []*widget.FormItem{ // we can specify items in the constructor
-			{Text: "Entry", Widget: entry}},
-		OnSubmit: func() { // optional, handle form submission
-			log.Println("Form submitted:", entry.Text)
-			log.Println("multiline:", textArea.Text)
-			myWindow.Close()
-		},
+func main() {
+	listDemos := flag.Bool("list", false, "list the demos and exit")
+	name := flag.String("demo", "", "name of the demo to run (default: open the launcher window)")
+	seed := flag.Int64("seed", 0, "seed for the demos' random numbers (0 uses the current time)")
+	flag.Parse()
+
+	if *listDemos {
+		printDemos()
+		return
 	}
 
-	// we can also append items
-	form.Append("Text", textArea)
-
-	myWindow.SetContent(form)
-	myWindow.ShowAndRun()
-}
-
-func progressBar() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("ProgressBar Widget")
-
-	progress := widget.NewProgressBar()
-	infinite := widget.NewProgressBarInfinite()
-
-	go func() {
-		for i := 0.0; i <= 1.0; i += 0.1 {
-			time.Sleep(time.Millisecond * 250)
-			progress.SetValue(i)
+	var run func(fyne.App, demo.Env) fyne.Window = demo.Launcher
+	if *name != "" {
+		d, ok := demo.Find(*name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown demo %q, run with -list to see the demos\n", *name)
+			os.Exit(2)
 		}
-	}()
-
-	myWindow.SetContent(container.NewVBox(progress, infinite))
-	myWindow.ShowAndRun()
-}
-
-// page with multiple buttons at the top with content below, tracking mouse movements
-func toolbar() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Toolbar Widget")
-
-	toolbar := widget.NewToolbar(
-		widget.NewToolbarAction(theme.DocumentCreateIcon(), func() {
-			log.Println("New document")
-		}),
-		widget.NewToolbarSeparator(),
-		widget.NewToolbarAction(theme.ContentCutIcon(), func() {}),
-		widget.NewToolbarAction(theme.ContentCopyIcon(), func() {}),
-		widget.NewToolbarAction(theme.ContentPasteIcon(), func() {}),
-		widget.NewToolbarSpacer(),
-		widget.NewToolbarAction(theme.HelpIcon(), func() {
-			log.Println("Display help")
-		}),
-	)
-
-	content := container.NewBorder(toolbar, nil, nil, nil, widget.NewLabel("Content"))
-	myWindow.SetContent(content)
-	myWindow.ShowAndRun()
-}
-
-var dataList = []string{"a", "string", "list"}
-
-// list of items (clickable but does not do anything)
-func list() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("List Widget")
-
-	list := widget.NewList(
-		func() int {
-			return len(dataList)
-		},
-		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
-		},
-		func(i widget.ListItemID, o fyne.CanvasObject) {
-			o.(*widget.Label).SetText(dataList[i])
-		})
-
-	myWindow.SetContent(list)
-	myWindow.ShowAndRun()
-}
-
-var dataTable = [][]string{[]string{"top left", "top right"},
-	[]string{"bottom left", "bottom right"}}
-
-// table with 2 columns and rows
-func table() {
-	myApp := app.New()
-	myWindow := myApp.NewWindow("Table Widget")
-
-	list := widget.NewTable(
-		func() (int, int) {
-			return len(dataTable), len(dataTable[0])
-		},
-		func() fyne.CanvasObject {
-			return widget.NewLabel("wide content")
-		},
-		func(i widget.TableCellID, o fyne.CanvasObject) {
-			o.(*widget.Label).SetText(dataTable[i.Row][i.Col])
-		})
-
-	myWindow.SetContent(list)
-	myWindow.ShowAndRun()
-}
-
-func dataBinding() {
-	boundString := binding.NewString()
-	s, _ := boundString.Get()
-	log.Printf("Bound = '%s'", s)
-
-	myInt := 5
-	boundInt := binding.BindInt(&myInt)
-	i, _ := boundInt.Get()
-	log.Printf("Source = %d, bound = %d", myInt, i)
-}
-
-// change from initial value to another value when increase widget size
-func bindingSimpleWidgets() {
-	myApp := app.New()
-	w := myApp.NewWindow("Simple")
-
-	str := binding.NewString()
-	str.Set("Initial value")
-
-	text := widget.NewLabelWithData(str)
-	w.SetContent(text)
-
-	go func() {
-		time.Sleep(time.Second * 2)
-		str.Set("A new string")
-	}()
-
-	w.ShowAndRun()
-}
-
-// editible list, where if you edit one element the other element also gets edited
-func twoWayBinding() {
-	myApp := app.New()
-	w := myApp.NewWindow("Two Way")
-
-	str := binding.NewString()
-	str.Set("Hi!")
-
-	w.SetContent(container.NewVBox(
-		widget.NewLabelWithData(str),
-		widget.NewEntryWithData(str),
-	))
-
-	w.ShowAndRun()
-}
-
-// slider/scroller bar which converts percentage to decimal in real time
-func conversion() {
-	myApp := app.New()
-	w := myApp.NewWindow("Conversion")
-
-	f := binding.NewFloat()
-	str := binding.FloatToString(f)
-	short := binding.FloatToStringWithFormat(f, "%0.0f%%")
-	f.Set(25.0)
-
-	w.SetContent(container.NewVBox(
-		widget.NewSliderWithData(0, 100.0, f),
-		widget.NewLabelWithData(str),
-		widget.NewLabelWithData(short),
-	))
+		run = d.Window
+	}
 
-	w.ShowAndRun()
-}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	env := demo.Env{Log: log.Default(), Rand: rand.NewSource(*seed), Clock: clock.Real}
 
-// list of items which appends another item upon clicking a button
-func listData() {
 	myApp := app.New()
-	myWindow := myApp.NewWindow("List Data")
-
-	data := binding.BindStringList(
-		&[]string{"Item 1", "Item 2", "Item 3"},
-	)
-
-	list := widget.NewListWithData(data,
-		func() fyne.CanvasObject {
-			return widget.NewLabel("template")
-		},
-		func(i binding.DataItem, o fyne.CanvasObject) {
-			o.(*widget.Label).Bind(i.(binding.String))
-		})
-
-	add := widget.NewButton("Append", func() {
-		val := fmt.Sprintf("Item %d", data.Length()+1)
-		data.Append(val)
-	})
-	myWindow.SetContent(container.NewBorder(nil, add, nil, nil, list))
-	myWindow.ShowAndRun()
+	run(myApp, env).ShowAndRun()
 }
 
-func main() {
-	// introduction()
-	// windowHandling()
-	// canvasObject()
-	// containerLayout()
-	// widgets()
-	// rectangle()
-	// text()
-	// line()
-	// circle()
-	// image()
-	// raster()
-	// gradient()
-	// appTabsContainer()
-	// boxContainer()
-	// button()
-	// entry()
-	choices()
-	// form()
-	// progressBar()
-	// toolbar()
-	// list()
-	// table()
-	// dataBinding()
-	// bindingSimpleWidgets()
-	// twoWayBinding()
-	// conversion()
-	// listData()
+// print demos grouped by category
+func printDemos() {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, c := range demo.Categories {
+		for _, d := range demo.InCategory(c) {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Name, d.Category, d.Description)
+		}
+	}
+	tw.Flush()
 }
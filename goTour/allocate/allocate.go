@@ -0,0 +1,100 @@
+// Package allocate divides an integer total into parts proportional to integer weights
+// without losing anything to rounding, generalising the basics lesson's split(sum)
+// which hardcodes a 4/9 ratio and hands the truncated remainder to the second value.
+package allocate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+// errors for invalid weight vectors
+var (
+	ErrNoWeights       = errors.New("allocate: no weights")
+	ErrNegativeWeight  = errors.New("allocate: negative weight")
+	ErrZeroTotalWeight = errors.New("allocate: weights add up to zero")
+	ErrWeightOverflow  = errors.New("allocate: weights add up to more than math.MaxInt64")
+)
+
+// Allocate divides total into len(weights) parts proportional to the weights using the
+// largest remainder method: every part gets its truncated share and the units left over
+// go to the parts with the largest remainders. Ties go to the larger weight, then to the
+// lower index, so the result is deterministic.
+//
+// The parts always add up to total. A negative total is allocated like its absolute value
+// with every part negated, and parts with a zero weight are always 0.
+//
+//	Allocate(17, []int{4, 5}) // [8 9] (split(17) gives 7 10)
+func Allocate(total int, weights []int) ([]int, error) {
+	if len(weights) == 0 {
+		return nil, ErrNoWeights
+	}
+	var sum uint64
+	for i, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("%w: weights[%d] = %d", ErrNegativeWeight, i, w)
+		}
+		sum += uint64(w)
+		if sum > math.MaxInt64 {
+			return nil, ErrWeightOverflow
+		}
+	}
+	if sum == 0 {
+		return nil, ErrZeroTotalWeight
+	}
+
+	// work with |total|: math.MinInt64 still fits in a uint64
+	negative := total < 0
+	abs := uint64(total)
+	if negative {
+		abs = -abs
+	}
+
+	parts := make([]uint64, len(weights))
+	remainders := make([]uint64, len(weights))
+	var allocated uint64
+	for i, w := range weights {
+		// abs*w / sum with a 128 bit product, so nothing overflows or gets rounded
+		hi, lo := bits.Mul64(abs, uint64(w))
+		parts[i], remainders[i] = bits.Div64(hi, lo, sum)
+		allocated += parts[i]
+	}
+
+	// hand out what's left (fewer units than there are weights) by largest remainder
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if remainders[i] != remainders[j] {
+			return remainders[i] > remainders[j]
+		}
+		return weights[i] > weights[j] // equal weights keep index order
+	})
+	for _, i := range order[:abs-allocated] {
+		parts[i]++
+	}
+
+	result := make([]int, len(parts))
+	for i, p := range parts {
+		result[i] = int(p)
+		if negative {
+			result[i] = int(-p)
+		}
+	}
+	return result, nil
+}
+
+// Split divides sum into two parts with the ratio x:y, like the basics lesson's split
+// but with the rounding remainder going to the part with the larger fractional share.
+func Split(sum, x, y int) (int, int, error) {
+	parts, err := Allocate(sum, []int{x, y})
+	if err != nil {
+		return 0, 0, err
+	}
+	return parts[0], parts[1], nil
+}
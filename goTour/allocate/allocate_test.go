@@ -0,0 +1,120 @@
+package allocate
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total   int
+		weights []int
+		want    []int
+	}{
+		{17, []int{4, 5}, []int{8, 9}},
+		{100, []int{1, 1, 1}, []int{34, 33, 33}}, // tie on remainder and weight: lowest index
+		{2, []int{1, 3}, []int{0, 2}},            // tie on remainder: larger weight
+		{7, []int{1}, []int{7}},
+		{0, []int{3, 4}, []int{0, 0}},
+		{5, []int{0, 1, 0}, []int{0, 5, 0}},
+		{1, []int{0, 5, 5}, []int{0, 1, 0}},
+		{-17, []int{4, 5}, []int{-8, -9}},
+		{-100, []int{1, 1, 1}, []int{-34, -33, -33}},
+		{2, []int{3, 3, 3}, []int{1, 1, 0}},
+		{9, []int{1, 2, 3, 4}, []int{1, 2, 3, 3}},
+		// products past 64 bits
+		{math.MaxInt64, []int{math.MaxInt64 - 1, 1}, []int{math.MaxInt64 - 1, 1}},
+		{math.MaxInt64, []int{1, 1}, []int{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+		{math.MinInt64, []int{1, 1}, []int{math.MinInt64 / 2, math.MinInt64 / 2}},
+		{math.MinInt64, []int{1}, []int{math.MinInt64}},
+		{math.MinInt64, []int{3, 0}, []int{math.MinInt64, 0}},
+	}
+	for _, tt := range tests {
+		got, err := Allocate(tt.total, tt.weights)
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Allocate(%d, %v) = %v, %v, want %v", tt.total, tt.weights, got, err, tt.want)
+		}
+	}
+}
+
+func TestAllocateErrors(t *testing.T) {
+	tests := []struct {
+		weights []int
+		err     error
+	}{
+		{nil, ErrNoWeights},
+		{[]int{}, ErrNoWeights},
+		{[]int{1, -1}, ErrNegativeWeight},
+		{[]int{0, 0}, ErrZeroTotalWeight},
+		{[]int{math.MaxInt64, 1}, ErrWeightOverflow},
+		{[]int{math.MaxInt64 / 2, math.MaxInt64 / 2, 2}, ErrWeightOverflow},
+	}
+	for _, tt := range tests {
+		if got, err := Allocate(10, tt.weights); !errors.Is(err, tt.err) || got != nil {
+			t.Errorf("Allocate(10, %v) = %v, %v, want %v", tt.weights, got, err, tt.err)
+		}
+	}
+}
+
+// every part is its exact share, floor or ceiling, and the parts add up to total
+func TestAllocateMatchesExactShares(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 2000; n++ {
+		total := rnd.Int63() >> rnd.Intn(63)
+		if rnd.Intn(2) == 0 {
+			total = -total
+		}
+		weights := make([]int, 1+rnd.Intn(6))
+		for i := range weights {
+			weights[i] = int(rnd.Int63n(1 << (1 + rnd.Intn(60))))
+		}
+		weights[0]++ // never all zero
+		parts, err := Allocate(int(total), weights)
+		if err != nil {
+			t.Fatalf("Allocate(%d, %v): %v", total, weights, err)
+		}
+
+		var sumWeights, sumParts big.Int
+		for _, w := range weights {
+			sumWeights.Add(&sumWeights, big.NewInt(int64(w)))
+		}
+		abs := new(big.Int).Abs(big.NewInt(total))
+		for i, w := range weights {
+			// |part| is floor(|total|*w/sum) or one more
+			share := new(big.Int).Mul(abs, big.NewInt(int64(w)))
+			share.Quo(share, &sumWeights)
+			p := new(big.Int).Abs(big.NewInt(int64(parts[i])))
+			if d := new(big.Int).Sub(p, share); d.Sign() < 0 || d.Cmp(big.NewInt(1)) > 0 {
+				t.Fatalf("Allocate(%d, %v)[%d] = %d, exact share is %v", total, weights, i, parts[i], share)
+			}
+			sumParts.Add(&sumParts, big.NewInt(int64(parts[i])))
+		}
+		if sumParts.Cmp(big.NewInt(total)) != 0 {
+			t.Fatalf("Allocate(%d, %v) = %v adds up to %v", total, weights, parts, &sumParts)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		sum, x, y, a, b int
+	}{
+		{17, 4, 5, 8, 9},
+		{18, 4, 5, 8, 10},
+		{10, 1, 1, 5, 5},
+		{11, 1, 1, 6, 5},
+		{9, 1, 0, 9, 0},
+	}
+	for _, tt := range tests {
+		if a, b, err := Split(tt.sum, tt.x, tt.y); a != tt.a || b != tt.b || err != nil {
+			t.Errorf("Split(%d, %d, %d) = %d, %d, %v, want %d, %d", tt.sum, tt.x, tt.y, a, b, err, tt.a, tt.b)
+		}
+	}
+	if _, _, err := Split(10, 0, 0); !errors.Is(err, ErrZeroTotalWeight) {
+		t.Errorf("Split(10, 0, 0) returned %v, want %v", err, ErrZeroTotalWeight)
+	}
+}
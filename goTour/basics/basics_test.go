@@ -0,0 +1,12 @@
+package basics
+
+import (
+	"testing"
+
+	"goTour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+	// hello is deterministic too: lessontest runs lessons with a fake clock and a fixed seed
+	lessontest.Golden(t, "basics")
+}
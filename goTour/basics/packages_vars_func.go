@@ -0,0 +1,129 @@
+// packages, variables, and functions
+package basics
+
+import (
+	"fmt" // format
+	"io"
+	"math"
+	"math/cmplx" // complex numbers
+	"math/rand"
+	"strconv" // convert element to string
+
+	"goTour/clock"
+	"goTour/lesson"
+)
+
+// package constants
+const Pi = 3.14
+const (
+	// Create a huge number by shifting a 1 bit left 100 places.
+	// In other words, the binary number that is 1 followed by 100 zeroes.
+	Big = 1 << 100
+	// Shift it right again 99 places, so we end up with 1<<1, or 2.
+	Small = Big >> 99
+)
+
+func needInt(x int) int { return x*10 + 1 }
+func needFloat(x float64) float64 {
+	return x * 0.1
+}
+
+// package variables
+var c, python, java bool // initialised to false
+var (
+	ToBe   bool       = false
+	MaxInt uint64     = 1<<64 - 1
+	z      complex128 = cmplx.Sqrt(-5 + 12i)
+)
+
+// adds to integers and returns result.
+func add(x, y int) int {
+	return x + y
+}
+
+// swaps given strings and returns result as 2 seperate strings.
+func swap(x, y string) (string, string) {
+	return y, x
+}
+
+// return named values
+func split(sum int) (x, y int) {
+	x = sum * 4 / 9
+	y = sum - x
+	return
+}
+
+// say hello, print the time on c and a random number drawn from src
+func Hello(w io.Writer, c clock.Clock, src rand.Source) {
+	fmt.Fprintln(w, "Hello, 世界! Welcome to the playground")
+	fmt.Fprintln(w, "The time is:", c.Now())
+
+	// the same seed gives the same fave number (gotour -seed)
+	rnd := rand.New(src)
+	fmt.Fprintln(w, "My fave number is ", rnd.Intn(10))
+
+	// print format
+	fmt.Fprintf(w, "now you have %g problems\n", math.Sqrt(7))
+}
+
+// calling functions with multiple parameters and results
+func Functions(w io.Writer) {
+	// call function
+	x := 1
+	y := 2
+	fmt.Fprintf(w, "%d + %d = %d\n", x, y, add(x, y))
+
+	// convert int to string
+	sx, sy := swap(strconv.Itoa(x), strconv.Itoa(y))
+	fmt.Fprintln(w, sx, sy)
+
+	// split a number into 2 numbers (sum of numbers equal orig num)
+	x, y = split(17)
+	fmt.Fprintln(w, x, y)
+}
+
+// declaring and initialising variables
+func Variables(w io.Writer) {
+	var i int // initialised to 0
+	var j, k int = 666, 420
+	fmt.Fprintln(w, i, j, k, c, python, java)
+}
+
+// basic types
+func BasicTypes(w io.Writer) {
+	fmt.Fprintf(w, "Type: %T Value: %v\n", ToBe, ToBe)
+	fmt.Fprintf(w, "Type: %T Value: %v\n", MaxInt, MaxInt)
+	fmt.Fprintf(w, "Type: %T Value: %v\n", z, z)
+
+	// type inference
+	v := 0.867 + 0.5i // change me!
+	fmt.Fprintf(w, "v is of type %T\n", v)
+}
+
+// constants can be character, string, boolean, or numeric values
+func Constants(w io.Writer) {
+	const World = "世界"
+	fmt.Fprintln(w, "Hello", World)
+	fmt.Fprintln(w, "Happy", Pi, "Day")
+
+	const Truth = true
+	fmt.Fprintln(w, "Go rules?", Truth)
+
+	// number constants
+	fmt.Fprintln(w, needInt(Small))
+	fmt.Fprintln(w, needFloat(Small))
+	fmt.Fprintln(w, needFloat(Big))
+	// overflows int (an int can store max 64-bit integer)
+	// fmt.Fprintln(w, needInt(Big))
+}
+
+func init() {
+	t := lesson.NewTopic(1, "basics", "Packages, variables, and functions")
+	t.AddEnv("hello", "printing, the time and random numbers", func(env lesson.Env) {
+		Hello(env.Out, env.Clock, env.Rand)
+	})
+	t.Add("functions", "functions with multiple and named results", Functions)
+	t.Add("variables", "variable declarations and zero values", Variables)
+	t.Add("basicTypes", "basic types and type inference", BasicTypes)
+	t.Add("constants", "string, boolean and numeric constants", Constants)
+}
@@ -0,0 +1,25 @@
+// Package clock lets code that reads the time or waits for it take a Clock instead of
+// calling package time directly, so tests can use a Fake clock and move time forward
+// themselves (e.g. to check each branch of the flow control lesson's time switches).
+package clock
+
+import "time"
+
+// source of the current time and of timers
+type Clock interface {
+	Now() time.Time                         // current time, in the clock's location
+	Sleep(d time.Duration)                  // block until d has passed
+	After(d time.Duration) <-chan time.Time // channel that gets the time once d has passed
+	Tick(d time.Duration) <-chan time.Time  // channel that gets the time every d (nil if d <= 0)
+}
+
+// Real is the clock of package time
+var Real Clock = realClock{}
+
+// clock backed by package time
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) Tick(d time.Duration) <-chan time.Time  { return time.Tick(d) }
@@ -0,0 +1,152 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a clock that only moves when told to. Sleep, After and Tick wait for Advance
+// (or Set) to move the time past their deadline, unless AutoAdvance makes Sleep move it.
+// Safe for concurrent use.
+type Fake struct {
+	mu      sync.Mutex
+	changed *sync.Cond // signalled when a waiter is added
+	now     time.Time
+	waiters []*waiter
+	auto    bool // Sleep advances the time itself
+}
+
+// pending After or Tick channel
+type waiter struct {
+	when   time.Time
+	period time.Duration // 0 for After
+	c      chan time.Time
+}
+
+// NewFake returns a fake clock set to now. Now returns times in now's location, so the
+// location decides e.g. which weekday it is.
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Sleep blocks until the fake time has moved forward by d, or with AutoAdvance moves it
+// forward by d and returns
+func (f *Fake) Sleep(d time.Duration) {
+	f.mu.Lock()
+	if f.auto {
+		f.advanceTo(f.now.Add(d))
+		f.mu.Unlock()
+		return
+	}
+	f.mu.Unlock()
+	<-f.After(d)
+}
+
+// AutoAdvance makes Sleep move the fake time forward itself, firing the After and Tick
+// channels due on the way, instead of waiting for Advance. Code that sleeps in a single
+// goroutine, like a polling loop, then runs without a test driving the clock.
+func (f *Fake) AutoAdvance() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auto = true
+}
+
+// After returns a channel that gets the fake time once it has moved forward by d
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- f.now
+		return c
+	}
+	f.add(&waiter{when: f.now.Add(d), c: c})
+	return c
+}
+
+// Tick returns a channel that gets the fake time every time it moves past another d.
+// Like time.Tick, ticks are dropped if the receiver falls behind, and d <= 0 gives nil.
+func (f *Fake) Tick(d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := make(chan time.Time, 1)
+	f.add(&waiter{when: f.now.Add(d), period: d, c: c})
+	return c
+}
+
+// Advance moves the fake time forward by d, firing every After and Tick channel that is
+// due on the way in deadline order (each with the time of its deadline).
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceTo(f.now.Add(d))
+}
+
+// Set moves the fake time to t, firing due channels like Advance. Moving backwards
+// doesn't fire anything, and t's location becomes the location of Now.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceTo(t)
+	f.now = t
+}
+
+// BlockUntil waits until n Sleep, After or Tick calls are waiting on the clock, so a test
+// can be sure a goroutine has started sleeping before it advances the time.
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.waiters) < n {
+		f.changed.Wait()
+	}
+}
+
+// Waiters returns the number of Sleep, After and Tick calls waiting on the clock
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.waiters)
+}
+
+// add a waiter, keeping them sorted by deadline (f.mu must be held)
+func (f *Fake) add(w *waiter) {
+	f.waiters = append(f.waiters, w)
+	sort.SliceStable(f.waiters, func(i, j int) bool {
+		return f.waiters[i].when.Before(f.waiters[j].when)
+	})
+	f.changed.Broadcast()
+}
+
+// fire the waiters due by t and move the time to t (f.mu must be held)
+func (f *Fake) advanceTo(t time.Time) {
+	for len(f.waiters) > 0 && !f.waiters[0].when.After(t) {
+		w := f.waiters[0]
+		f.waiters = f.waiters[1:]
+		f.now = w.when.In(f.now.Location())
+
+		select {
+		case w.c <- f.now:
+		default: // receiver hasn't taken the last tick yet
+		}
+		if w.period > 0 {
+			w.when = w.when.Add(w.period)
+			f.add(w)
+		}
+	}
+	if t.After(f.now) {
+		f.now = t.In(f.now.Location())
+	}
+}
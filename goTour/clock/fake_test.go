@@ -0,0 +1,102 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var start = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+func TestFakeAfter(t *testing.T) {
+	f := NewFake(start)
+	c := f.After(2 * time.Second)
+
+	f.Advance(time.Second)
+	select {
+	case <-c:
+		t.Fatal("After fired a second early")
+	default:
+	}
+
+	f.Advance(5 * time.Second)
+	if got, want := <-c, start.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("After sent %v, want %v", got, want)
+	}
+	if got, want := f.Now(), start.Add(6*time.Second); !got.Equal(want) {
+		t.Errorf("Now() = %v, want %v", got, want)
+	}
+	if n := f.Waiters(); n != 0 {
+		t.Errorf("%d waiters left", n)
+	}
+}
+
+func TestFakeSleep(t *testing.T) {
+	f := NewFake(start)
+	done := make(chan time.Time)
+	go func() {
+		f.Sleep(time.Minute)
+		done <- f.Now()
+	}()
+
+	f.BlockUntil(1)
+	f.Advance(time.Minute)
+	if got, want := <-done, start.Add(time.Minute); !got.Equal(want) {
+		t.Errorf("woke up at %v, want %v", got, want)
+	}
+}
+
+func TestFakeTick(t *testing.T) {
+	f := NewFake(start)
+	c := f.Tick(time.Second)
+	for i := 1; i <= 3; i++ {
+		f.Advance(time.Second)
+		if got, want := <-c, start.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+			t.Errorf("tick %d at %v, want %v", i, got, want)
+		}
+	}
+
+	// ticks are dropped while the receiver isn't listening
+	f.Advance(10 * time.Second)
+	if got, want := <-c, start.Add(4*time.Second); !got.Equal(want) {
+		t.Errorf("first tick after falling behind at %v, want %v", got, want)
+	}
+	select {
+	case got := <-c:
+		t.Errorf("got extra tick at %v", got)
+	default:
+	}
+
+	if f.Tick(0) != nil {
+		t.Error("Tick(0) returned a channel")
+	}
+}
+
+func TestFakeSetLocation(t *testing.T) {
+	f := NewFake(start)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	f.Set(start.In(tokyo))
+	if got := f.Now().Weekday(); got != time.Wednesday {
+		t.Errorf("weekday in Tokyo = %v, want Wednesday", got)
+	}
+}
+
+func TestFakeAutoAdvance(t *testing.T) {
+	f := NewFake(start)
+	f.AutoAdvance()
+	tick, boom := f.Tick(100*time.Millisecond), f.After(250*time.Millisecond)
+	ticks := 0
+loop:
+	for {
+		select {
+		case <-tick:
+			ticks++
+		case <-boom:
+			break loop
+		default:
+			f.Sleep(50 * time.Millisecond)
+		}
+	}
+	if got, want := f.Now(), start.Add(250*time.Millisecond); !got.Equal(want) || ticks != 2 {
+		t.Errorf("boom at %v after %d ticks, want %v after 2", got, ticks, want)
+	}
+}
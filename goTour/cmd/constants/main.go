@@ -0,0 +1,65 @@
+// constants evaluates Go constant expressions with arbitrary precision and shows which
+// built-in numeric types can hold the result.
+//
+//	constants '1 << 100' 'Big >> 99' 'math.MaxInt64 + 1' '1e300 * 1e10'
+//
+// The basics lesson constants (Pi, Big, Small) and the math package constants
+// (math.Pi, math.MaxUint32, ...) can be used in the expressions.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"goTour/constants"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: constants <expression>...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if !explore(os.Stdout, os.Stderr, flag.Args()) {
+		os.Exit(1)
+	}
+}
+
+// report on each expression, with the errors going to errOut; false if any failed
+func explore(out, errOut io.Writer, exprs []string) bool {
+	env := constants.Default()
+	ok := true
+	for i, expr := range exprs {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		r, err := constants.Explore(expr, env)
+		if err != nil {
+			fmt.Fprintf(errOut, "%s: %v\n", expr, err)
+			ok = false
+			continue
+		}
+		printReport(out, r)
+	}
+	return ok
+}
+
+// print the value and a table of how it fits each type
+func printReport(w io.Writer, r constants.Report) {
+	fmt.Fprintf(w, "%s = %s\n", r.Expr, r.Exact)
+	fmt.Fprintf(w, "%s (default type %s), %d bits\n", r.Kind, r.Type, r.BitLen)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, t := range r.Types {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", t.Type, t.Fit, t.Converted)
+	}
+	tw.Flush()
+}
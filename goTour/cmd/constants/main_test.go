@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExplore(t *testing.T) {
+	tests := []struct {
+		exprs  []string
+		ok     bool
+		want   []string // starts of lines of the report, in order
+		errOut string
+	}{
+		{
+			[]string{"Big >> 99"},
+			true,
+			[]string{"Big >> 99 = 2", "untyped int (default type int), 2 bits", "  int8        exact  2", "  complex128  exact  (2+0i)"},
+			"",
+		},
+		{
+			[]string{"1.5", "1 << 100"},
+			true,
+			[]string{"1.5 = 1.5", "  int8        truncated  1", "  float64     exact      1.5", "1 << 100 = 1267650600228229401496703205376", "  uint64      overflow"},
+			"",
+		},
+		{
+			[]string{"x", "math.MaxUint8"},
+			false,
+			[]string{"math.MaxUint8 = 255", "  int8        overflow"},
+			"x: undefined: x\n",
+		},
+	}
+	for _, tt := range tests {
+		var out, errOut strings.Builder
+		if ok := explore(&out, &errOut, tt.exprs); ok != tt.ok {
+			t.Errorf("explore(%q) = %v, want %v", tt.exprs, ok, tt.ok)
+		}
+		want := tt.want
+		for _, line := range strings.Split(out.String(), "\n") {
+			if len(want) > 0 && strings.HasPrefix(line, want[0]) {
+				want = want[1:]
+			}
+		}
+		if len(want) > 0 {
+			t.Errorf("explore(%q) output has no line starting %q after the earlier ones:\n%s", tt.exprs, want[0], out.String())
+		}
+		if errOut.String() != tt.errOut {
+			t.Errorf("explore(%q) errors = %q, want %q", tt.exprs, errOut.String(), tt.errOut)
+		}
+	}
+}
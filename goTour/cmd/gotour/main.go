@@ -0,0 +1,139 @@
+// gotour lists and runs the Go tour lessons.
+//
+//	gotour list [topic]               list topics and lessons
+//	gotour run <topic/lesson|topic>... run lessons (a topic runs all its lessons)
+//
+// Lessons that print random numbers draw them from a source seeded with -seed, so
+// gotour -seed 42 run basics/hello prints the same fave number every time.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
+	"goTour/tracer"
+
+	// topics register their lessons on import
+	_ "goTour/basics"
+	_ "goTour/concurrency"
+	_ "goTour/flowcontrol"
+	_ "goTour/methods"
+	_ "goTour/moretypes"
+)
+
+const usage = `usage:
+	gotour [-seed n] list [topic]                list topics and lessons
+	gotour [-seed n] run <topic/lesson|topic>... run lessons (a topic runs all its lessons)
+
+	-seed n  seed for the lessons' random numbers (default: the current time)
+`
+
+// returned by run when a lesson panicked (already reported, so no usage message)
+var errPanicked = errors.New("lessons panicked")
+
+var seed = flag.Int64("seed", 0, "seed for the lessons' random numbers (0 uses the current time)")
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if flag.NArg() < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	// one source for the whole run: with a fixed seed the output only depends on the
+	// lessons given and their order
+	env := lesson.Env{Out: os.Stdout, Rand: rand.NewSource(*seed), Clock: clock.Real}
+	var err error
+	switch cmd, args := flag.Arg(0), flag.Args()[1:]; cmd {
+	case "list":
+		err = list(os.Stdout, args)
+	case "run":
+		err = run(env, os.Stderr, args)
+	case "help":
+		fmt.Print(usage)
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", cmd)
+	}
+	if err == errPanicked {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gotour:", err)
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// print topics and their lessons
+func list(w io.Writer, args []string) error {
+	topics := lesson.Topics()
+	if len(args) > 0 {
+		topics = nil
+		for _, name := range args {
+			t := lesson.FindTopic(name)
+			if t == nil {
+				return fmt.Errorf("unknown topic %q", name)
+			}
+			topics = append(topics, t)
+		}
+	}
+
+	for _, t := range topics {
+		fmt.Fprintf(w, "%s: %s\n", t.Name, t.Doc)
+		for _, l := range t.Lessons {
+			fmt.Fprintf(w, "  %-30s %s\n", l.ID(), l.Doc)
+		}
+	}
+	return nil
+}
+
+// run the given lessons in order in env, reporting panics to errOut
+func run(env lesson.Env, errOut io.Writer, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("run: no lessons given")
+	}
+
+	// resolve everything first so a typo doesn't leave a half finished run
+	var lessons []lesson.Lesson
+	for _, id := range args {
+		if t := lesson.FindTopic(id); t != nil {
+			lessons = append(lessons, t.Lessons...)
+			continue
+		}
+		l, ok := lesson.Find(id)
+		if !ok {
+			return fmt.Errorf("unknown lesson %q (see gotour list)", id)
+		}
+		lessons = append(lessons, l)
+	}
+
+	panicked := false
+	for _, l := range lessons {
+		if len(lessons) > 1 {
+			fmt.Fprintf(env.Out, "== %s ==\n", l.ID())
+		}
+		// a panicking lesson is reported with its stack and the rest still run
+		err := tracer.Catch(func() { l.Run(env) })
+		var pe *tracer.PanicError
+		if errors.As(err, &pe) {
+			fmt.Fprintf(errOut, "gotour: %s: %v\n\n%s\n", l.ID(), pe, pe.Stack)
+			panicked = true
+		}
+	}
+	if panicked {
+		return errPanicked
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
+)
+
+// topic with a lesson that panics, sorted after the tour's own topics
+var broken = lesson.NewTopic(100, "broken", "lessons that fail")
+
+func init() {
+	broken.Add("panics", "divides by zero", func(w io.Writer) {
+		fmt.Fprintln(w, "before")
+		var zero int
+		fmt.Fprintln(w, 1/zero)
+	})
+	broken.Add("fine", "prints fine", func(w io.Writer) { fmt.Fprintln(w, "fine") })
+}
+
+func testEnv(out io.Writer) lesson.Env {
+	return lesson.Env{Out: out, Rand: rand.NewSource(42), Clock: clock.NewFake(time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC))}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args    []string
+		want    string
+		err     error
+		errText string // in the error or what was written to errOut
+	}{
+		{
+			args: []string{"basics/functions"},
+			want: "1 + 2 = 3\n2 1\n7 10\n",
+		},
+		{
+			args: []string{"basics/hello", "broken/fine"},
+			want: "== basics/hello ==\nHello, 世界! Welcome to the playground\n" +
+				"The time is: 2009-11-10 23:00:00 +0000 UTC\nMy fave number is  5\n" +
+				"now you have 2.6457513110645907 problems\n== broken/fine ==\nfine\n",
+		},
+		{
+			// a panic is reported and the next lesson still runs
+			args:    []string{"broken"},
+			want:    "== broken/panics ==\nbefore\n== broken/fine ==\nfine\n",
+			err:     errPanicked,
+			errText: "gotour: broken/panics: panic: runtime error: integer divide by zero",
+		},
+		{args: nil, errText: "run: no lessons given"},
+		// nothing runs if one of the lessons doesn't exist
+		{args: []string{"basics/hello", "basics/nope"}, errText: `unknown lesson "basics/nope"`},
+	}
+	for _, tt := range tests {
+		var out, errOut strings.Builder
+		err := run(testEnv(&out), &errOut, tt.args)
+		if out.String() != tt.want {
+			t.Errorf("run(%q) wrote\n%s\nwant\n%s", tt.args, out.String(), tt.want)
+		}
+		if tt.errText == "" && err != nil || tt.err != nil && err != tt.err {
+			t.Errorf("run(%q) = %v, want %v", tt.args, err, tt.err)
+		}
+		if got := fmt.Sprint(err) + errOut.String(); !strings.Contains(got, tt.errText) {
+			t.Errorf("run(%q) reported %q, want %q", tt.args, got, tt.errText)
+		}
+	}
+}
+
+func TestList(t *testing.T) {
+	var out strings.Builder
+	if err := list(&out, []string{"broken"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "broken: lessons that fail\n" +
+		"  broken/panics                  divides by zero\n" +
+		"  broken/fine                    prints fine\n"
+	if out.String() != want {
+		t.Errorf("list(broken) wrote\n%s\nwant\n%s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := list(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{"basics: ", "  basics/hello ", "concurrency: ", "  methods/interfaces ", "broken: "} {
+		if !strings.Contains(out.String(), "\n"+line) && !strings.HasPrefix(out.String(), line) {
+			t.Errorf("list() output has no line starting %q:\n%s", line, out.String())
+		}
+	}
+	if err := list(&out, []string{"basics", "nope"}); err == nil || !strings.Contains(err.Error(), `"nope"`) {
+		t.Errorf("list(basics, nope) = %v, want an unknown topic error", err)
+	}
+}
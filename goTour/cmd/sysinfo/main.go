@@ -0,0 +1,57 @@
+// sysinfo prints the platform, Go version, CPUs, goroutines, memory statistics and
+// build information of the running binary, e.g. to attach to a bug report.
+//
+//	sysinfo             aligned text
+//	sysinfo -json       indented JSON
+//	sysinfo -o info.txt write the report to a file
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"goTour/sysinfo"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "print JSON instead of text")
+	output := flag.String("o", "", "write the report to this file instead of stdout")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: sysinfo [-json] [-o file]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	info := sysinfo.Collect()
+	write := info.WriteText
+	if *asJSON {
+		write = info.WriteJSON
+	}
+	if err := report(*output, write); err != nil {
+		fmt.Fprintln(os.Stderr, "sysinfo:", err)
+		os.Exit(1)
+	}
+}
+
+// write the report to stdout, or to the file name if there is one. The file is closed
+// before returning, so an error closing it (e.g. a full disk) is reported too.
+func report(name string, write func(io.Writer) error) error {
+	if name == "" {
+		return write(os.Stdout)
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReportToFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "info.txt")
+	write := func(w io.Writer) error {
+		_, err := io.WriteString(w, "go version: go1.23.0\n")
+		return err
+	}
+	if err := report(name, write); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(name); err != nil || string(b) != "go version: go1.23.0\n" {
+		t.Errorf("report file holds %q, %v", b, err)
+	}
+}
+
+func TestReportErrors(t *testing.T) {
+	dir := t.TempDir()
+	ok := func(io.Writer) error { return nil }
+	if err := report(filepath.Join(dir, "missing", "info.txt"), ok); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("report to a missing directory returned %v", err)
+	}
+
+	failed := errors.New("write failed")
+	if err := report(filepath.Join(dir, "info.txt"), func(io.Writer) error { return failed }); err != failed {
+		t.Errorf("report returned %v, want the write error", err)
+	}
+}
@@ -0,0 +1,130 @@
+// tictactoe is a game of tic-tac-toe at the terminal, for two players taking turns or
+// against the computer, on the standard board or any m,n,k board.
+//
+//	tictactoe                          new game
+//	tictactoe -position X_X/O_X/__O    continue from a position (see tictactoe.Load)
+//	tictactoe -rows 7 -cols 7 -k 4     7x7 board, 4 in a row wins
+//	tictactoe -ai hard                 play X against the computer
+//	tictactoe -ai perfect -as O        play O, the computer moves first
+//
+// Enter moves as "row column" counting from 1 (e.g. "2 3"), "u" to undo the last move,
+// "p" to print the position in its compact form and "q" to quit.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	"goTour/tictactoe"
+)
+
+func main() {
+	position := flag.String("position", "", "position to start from, e.g. X_X/O_X/__O")
+	rows := flag.Int("rows", tictactoe.Size, "number of rows")
+	cols := flag.Int("cols", tictactoe.Size, "number of columns")
+	k := flag.Int("k", tictactoe.InARow, "marks in a row that win")
+	ai := flag.String("ai", "", "play against the computer: easy, medium, hard or perfect")
+	as := flag.String("as", tictactoe.X, "side you play against the computer (X or O)")
+	budget := flag.Duration("budget", tictactoe.DefaultBudget, "time the computer may think per move")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: tictactoe [-position board | -rows m -cols n -k k] [-ai difficulty [-as X|O] [-budget d]]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 0 || (*as != tictactoe.X && *as != tictactoe.O) {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var g *tictactoe.Game
+	var err error
+	if *position != "" {
+		g, err = tictactoe.Load(*position)
+	} else {
+		g, err = tictactoe.NewGameWith(tictactoe.Rules{M: *rows, N: *cols, K: *k})
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var computer *tictactoe.AI
+	if *ai != "" {
+		d, err := tictactoe.ParseDifficulty(*ai)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		computer = tictactoe.NewAI(d, rand.NewSource(time.Now().UnixNano()))
+		computer.Budget = *budget
+	}
+	play(g, computer, *as, os.Stdin, os.Stdout)
+}
+
+// read commands from in until the game is over or the input ends; if computer isn't
+// nil it plays the side that isn't human
+func play(g *tictactoe.Game, computer *tictactoe.AI, human string, in io.Reader, out io.Writer) {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, g)
+		if s := g.Status(); s != tictactoe.InProgress {
+			fmt.Fprintf(out, "%s!\n", s)
+			return
+		}
+		if computer != nil && g.Turn() != human {
+			m, err := computer.Move(g)
+			if err == nil {
+				err = g.Play(m)
+			}
+			if err != nil {
+				fmt.Fprintln(out, err)
+				return
+			}
+			fmt.Fprintf(out, "%s plays %d %d\n", g.Board()[m.Row][m.Col], m.Row+1, m.Col+1)
+			continue
+		}
+		fmt.Fprintf(out, "%s to move> ", g.Turn())
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return
+		}
+
+		switch cmd := strings.TrimSpace(scanner.Text()); cmd {
+		case "q", "quit":
+			return
+		case "u", "undo":
+			// against the computer take back its reply as well
+			if err := g.Undo(); err != nil {
+				fmt.Fprintln(out, err)
+			} else if computer != nil && g.Turn() != human {
+				g.Undo()
+			}
+		case "p", "position":
+			fmt.Fprintln(out, g.Encode())
+		default:
+			var m tictactoe.Move
+			if _, err := fmt.Sscanf(cmd, "%d %d", &m.Row, &m.Col); err != nil {
+				fmt.Fprintln(out, `enter "row column" (e.g. "2 3"), "u" to undo, "p" for the position or "q" to quit`)
+				continue
+			}
+			// the engine counts from 0
+			err := g.Play(tictactoe.Move{Row: m.Row - 1, Col: m.Col - 1})
+			switch {
+			case errors.Is(err, tictactoe.ErrOutOfRange):
+				r := g.Rules()
+				fmt.Fprintf(out, "%d %d is not on the board, rows go from 1 to %d and columns from 1 to %d\n", m.Row, m.Col, r.M, r.N)
+			case errors.Is(err, tictactoe.ErrOccupied):
+				fmt.Fprintf(out, "%d %d is already taken\n", m.Row, m.Col)
+			case err != nil:
+				fmt.Fprintln(out, err)
+			}
+		}
+	}
+}
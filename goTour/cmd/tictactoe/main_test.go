@@ -0,0 +1,112 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"goTour/tictactoe"
+)
+
+func TestPlay(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		input    string
+		want     []string // lines the output must contain, in order
+		end      string   // position at the end
+	}{
+		{
+			"X wins",
+			"",
+			"1 1\n2 1\n1 2\n2 2\n1 3\n",
+			[]string{"X to move> ", "O to move> ", "X X X", "X wins!"},
+			"XXX/OO_/___",
+		},
+		{
+			"bad input",
+			"",
+			"2 2\n2 2\n4 1\nhello\nq\n",
+			[]string{"2 2 is already taken", "4 1 is not on the board, rows go from 1 to 3 and columns from 1 to 3", `enter "row column"`},
+			"___/_X_/___",
+		},
+		{
+			"undo and print the position",
+			"",
+			"1 1\n2 2\nu\np\n",
+			[]string{"X__/___/___"},
+			"X__/___/___",
+		},
+		{
+			"nothing to undo",
+			"",
+			"u\n",
+			[]string{tictactoe.ErrNothingToUndo.Error()},
+			"___/___/___",
+		},
+		{
+			"loaded position",
+			"XX_/OO_/___",
+			"1 3\n",
+			[]string{"X X X", "X wins!"},
+			"XXX/OO_/___",
+		},
+		{
+			"finished position",
+			"XOX/XOO/OXX",
+			"",
+			[]string{"draw!"},
+			"XOX/XOO/OXX",
+		},
+	}
+	for _, tt := range tests {
+		g := tictactoe.NewGame()
+		if tt.position != "" {
+			var err error
+			if g, err = tictactoe.Load(tt.position); err != nil {
+				t.Fatal(err)
+			}
+		}
+		var out strings.Builder
+		play(g, nil, tictactoe.X, strings.NewReader(tt.input), &out)
+		checkOutput(t, tt.name, out.String(), tt.want)
+		if got := g.Encode(); got != tt.end {
+			t.Errorf("%s: ended at %s, want %s", tt.name, got, tt.end)
+		}
+	}
+}
+
+func TestPlayAgainstComputer(t *testing.T) {
+	// the computer moves first as X and takes the centre; undo takes back the human's
+	// move and the computer's reply, then the input ends
+	g := tictactoe.NewGame()
+	ai := tictactoe.NewAI(tictactoe.Perfect, rand.NewSource(1))
+	var out strings.Builder
+	play(g, ai, tictactoe.O, strings.NewReader("1 1\nu\n"), &out)
+	checkOutput(t, "computer as X", out.String(), []string{"X plays 2 2", "O to move> ", "X plays", "O to move> "})
+	if got := g.Encode(); got != "___/_X_/___" {
+		t.Errorf("after undo: %s, want the computer's first move only", got)
+	}
+
+	// the perfect computer never loses to the first free cell
+	g = tictactoe.NewGame()
+	out.Reset()
+	play(g, ai, tictactoe.X, strings.NewReader("1 1\n1 2\n1 3\n2 1\n2 2\n2 3\n3 1\n3 2\n3 3\n"), &out)
+	if s := g.Status(); s != tictactoe.OWins && s != tictactoe.Draw {
+		t.Errorf("game against the computer ended with %v:\n%s", s, out.String())
+	}
+}
+
+// check out contains the lines of want in order
+func checkOutput(t *testing.T, name, out string, want []string) {
+	t.Helper()
+	rest := out
+	for _, w := range want {
+		i := strings.Index(rest, w)
+		if i < 0 {
+			t.Errorf("%s: output has no %q after the earlier lines:\n%s", name, w, out)
+			return
+		}
+		rest = rest[i+len(w):]
+	}
+}
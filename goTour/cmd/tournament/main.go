@@ -0,0 +1,94 @@
+// tournament plays tic-tac-toe AIs against each other and prints their results.
+//
+//	tournament                              perfect against perfect, 3x3, 10 games
+//	tournament -a hard -b easy -games 100   hard against easy
+//	tournament -rows 7 -cols 7 -k 4 -budget 200ms
+//
+// The players take turns moving first. Results are from A's point of view.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"goTour/clock"
+	"goTour/tictactoe"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], clock.Real, os.Stdout, os.Stderr))
+}
+
+// run the command with the given arguments, timing it and the players' moves with c, and
+// return the exit status
+func run(args []string, c clock.Clock, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("tournament", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	rows := flags.Int("rows", tictactoe.Size, "number of rows")
+	cols := flags.Int("cols", tictactoe.Size, "number of columns")
+	k := flags.Int("k", tictactoe.InARow, "marks in a row that win")
+	a := flags.String("a", "perfect", "difficulty of player A: easy, medium, hard or perfect")
+	b := flags.String("b", "perfect", "difficulty of player B")
+	games := flags.Int("games", 10, "number of games")
+	budget := flags.Duration("budget", tictactoe.DefaultBudget, "time each player may think per move")
+	seed := flags.Int64("seed", 0, "seed for the players' random moves (0 picks one from the time)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tournament [-rows m -cols n -k k] [-a difficulty] [-b difficulty] [-games n] [-budget d] [-seed n]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		return 2
+	}
+	if flags.NArg() > 0 || *games < 1 {
+		flags.Usage()
+		return 2
+	}
+	if *seed == 0 {
+		*seed = c.Now().UnixNano()
+	}
+
+	players := make([]*tictactoe.AI, 2)
+	for i, name := range []string{*a, *b} {
+		d, err := tictactoe.ParseDifficulty(name)
+		if err != nil {
+			fmt.Fprintln(stderr, "tournament:", err)
+			return 2
+		}
+		// each player gets its own source so A's moves don't depend on B's
+		players[i] = tictactoe.NewAI(d, rand.NewSource(*seed+int64(i)))
+		players[i].Budget = *budget
+		players[i].Clock = c
+	}
+
+	r := tictactoe.Rules{M: *rows, N: *cols, K: *k}
+	start := c.Now()
+	stats, err := tictactoe.Tournament(r, players[0], players[1], *games)
+	if err != nil {
+		fmt.Fprintln(stderr, "tournament:", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: A (%s) against B (%s), seed %d\n\n", r, *a, *b, *seed)
+	printStats(stdout, stats)
+	fmt.Fprintf(stdout, "\n%d moves in %v\n", stats.Moves, c.Now().Sub(start).Round(time.Millisecond))
+	return 0
+}
+
+// table of A's results as X, as O and in total
+func printStats(w io.Writer, s tictactoe.Stats) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "A\tgames\twins\tdraws\tlosses\t")
+	for _, row := range []struct {
+		name string
+		rec  tictactoe.Record
+	}{{"as X", s.AsX}, {"as O", s.AsO}, {"total", s.Total}} {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%d\t\n", row.name, row.rec.Games(), row.rec.Wins, row.rec.Draws, row.rec.Losses)
+	}
+	tw.Flush()
+}
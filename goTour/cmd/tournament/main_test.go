@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"goTour/clock"
+	"goTour/tictactoe"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		status int
+		stdout string
+		stderr string // start of the error output
+	}{
+		{
+			// the fake clock stands still, so the players search every move to the end
+			name: "perfect players",
+			args: []string{"-games", "2", "-seed", "1"},
+			stdout: "3x3, 3 in a row: A (perfect) against B (perfect), seed 1\n\n" +
+				"      A  games  wins  draws  losses\n" +
+				"   as X      1     0      1       0\n" +
+				"   as O      1     0      1       0\n" +
+				"  total      2     0      2       0\n" +
+				"\n18 moves in 0s\n",
+		},
+		{name: "unknown difficulty", args: []string{"-a", "impossible"}, status: 2, stderr: "tournament: "},
+		{name: "no games", args: []string{"-games", "0"}, status: 2, stderr: "usage: tournament"},
+		{name: "extra argument", args: []string{"now"}, status: 2, stderr: "usage: tournament"},
+		{name: "unknown flag", args: []string{"-nope"}, status: 2, stderr: "flag provided but not defined: -nope"},
+		{name: "invalid rules", args: []string{"-k", "4"}, status: 1, stderr: "tournament: "},
+		{name: "help", args: []string{"-h"}, status: 0, stderr: "usage: tournament"},
+	}
+	for _, tt := range tests {
+		c := clock.NewFake(time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC))
+		var stdout, stderr strings.Builder
+		if status := run(tt.args, c, &stdout, &stderr); status != tt.status {
+			t.Errorf("%s: exit status %d, want %d (stderr %q)", tt.name, status, tt.status, stderr.String())
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%s: wrote\n%s\nwant\n%s", tt.name, stdout.String(), tt.stdout)
+		}
+		if !strings.HasPrefix(stderr.String(), tt.stderr) || tt.stderr == "" && stderr.Len() > 0 {
+			t.Errorf("%s: stderr %q, want it to start with %q", tt.name, stderr.String(), tt.stderr)
+		}
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	s := tictactoe.Stats{
+		Rules: tictactoe.Standard,
+		AsX:   tictactoe.Record{Wins: 12, Draws: 3},
+		AsO:   tictactoe.Record{Draws: 14, Losses: 1},
+		Total: tictactoe.Record{Wins: 12, Draws: 17, Losses: 1},
+	}
+	want := "      A  games  wins  draws  losses\n" +
+		"   as X     15    12      3       0\n" +
+		"   as O     15     0     14       1\n" +
+		"  total     30    12     17       1\n"
+	var out strings.Builder
+	printStats(&out, s)
+	if out.String() != want {
+		t.Errorf("printStats wrote\n%q\nwant\n%q", out.String(), want)
+	}
+}
@@ -0,0 +1,204 @@
+// wordcount counts the words in files or standard input, like the tour's WordCount
+// exercise but with Unicode words, case folding and punctuation stripping (see the
+// wordcount package).
+//
+//	wordcount notes.txt                    words of one file, most frequent first
+//	wordcount -top 10 -stop 'logs/*.log'   ten most frequent words in the logs, leaving out stop words
+//	wordcount -per-file -format csv *.txt  counts of each file as CSV
+//	cat notes.txt | wordcount -sort word   words of standard input in alphabetical order
+//
+// Arguments may be glob patterns, "-" reads standard input. The exit status is 0 if words
+// were counted, 1 if an input couldn't be read (or the output written), 2 for bad usage,
+// 3 if the inputs hold no words and 130 if interrupted.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"goTour/sysinfo"
+	"goTour/wordcount"
+)
+
+// exit codes
+const (
+	exitUnreadable = 1 // an input couldn't be read (the others are still counted) or the output written
+	exitUsage      = 2
+	exitEmpty      = 3   // every input was read but no words were counted
+	exitInterrupt  = 130 // stopped by SIGINT (128 + the signal number, as shells report it)
+)
+
+// counts of one input, or of all of them merged
+type report struct {
+	File   string               `json:"file,omitempty"`
+	Words  int                  `json:"words"`  // words counted
+	Unique int                  `json:"unique"` // different words
+	Counts []wordcount.WordFreq `json:"counts"`
+}
+
+func main() {
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt)
+	status := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stopSignals()
+	os.Exit(status)
+}
+
+// run the command with the given arguments and files, returning the exit status
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("wordcount", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "table", "output format: table, json or csv")
+	sortBy := flags.String("sort", "count", "order of the words: count or word")
+	top := flags.Int("top", 0, "only show the `N` most frequent words (0 for all)")
+	perFile := flags.Bool("per-file", false, "report each input separately instead of merging the counts")
+	keepCase := flags.Bool("keep-case", false, "count \"Go\" and \"go\" as different words")
+	keepPunct := flags.Bool("keep-punct", false, "split on white space only, keeping punctuation (like the tour's WordCount)")
+	stem := flags.Bool("stem", false, "count English words by their stem (connect, connected, connection...)")
+	stop := flags.Bool("stop", false, "leave out common English words")
+	minLength := flags.Int("min", 0, "shortest word counted, in characters")
+	workers := flags.Int("workers", 0, "goroutines counting each input (0 for one per CPU)")
+	progress := flags.Bool("progress", false, "show progress on standard error")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: wordcount [flags] [file or pattern]...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err == flag.ErrHelp {
+		return 0
+	} else if err != nil {
+		return exitUsage
+	}
+
+	write, ok := writers[*format]
+	if !ok || (*sortBy != "count" && *sortBy != "word") {
+		flags.Usage()
+		return exitUsage
+	}
+
+	opts := wordcount.StreamOptions{
+		Options: wordcount.Options{
+			KeepCase:        *keepCase,
+			KeepPunctuation: *keepPunct,
+			MinLength:       *minLength,
+		},
+		Workers: *workers,
+	}
+	if *stem {
+		opts.Stem = wordcount.Porter
+	}
+	if *stop {
+		opts.StopWords = wordcount.StopSet(wordcount.EnglishStopWords...)
+	}
+
+	status := 0
+	names, err := inputs(flags.Args())
+	if err != nil {
+		fmt.Fprintln(stderr, "wordcount:", err)
+		status = exitUnreadable
+	}
+	reports := []report{}
+	merged := make(map[string]int)
+	for _, name := range names {
+		if *progress {
+			opts.Progress = func(p wordcount.Progress) {
+				fmt.Fprintf(stderr, "\r%s: %s, %d words", displayName(name), sysinfo.Bytes(uint64(p.Bytes)), p.Words)
+			}
+		}
+		counts, err := count(ctx, name, stdin, opts)
+		if *progress {
+			fmt.Fprintln(stderr)
+		}
+		if ctx.Err() != nil {
+			fmt.Fprintln(stderr, "wordcount: interrupted")
+			return exitInterrupt
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "wordcount: %s: %v\n", displayName(name), err)
+			status = exitUnreadable
+			continue
+		}
+		if *perFile {
+			reports = append(reports, newReport(displayName(name), counts, *top, *sortBy))
+		}
+		wordcount.Merge(merged, counts)
+	}
+	if !*perFile {
+		reports = []report{newReport("", merged, *top, *sortBy)}
+	}
+
+	if err := write(stdout, reports, *perFile); err != nil {
+		fmt.Fprintln(stderr, "wordcount:", err)
+		return exitUnreadable
+	}
+	if status == 0 && len(merged) == 0 {
+		status = exitEmpty
+	}
+	return status
+}
+
+// expand the arguments into the inputs to count: glob patterns become the files they
+// match, no arguments means standard input ("-"). Patterns matching nothing are skipped
+// and reported in the error.
+func inputs(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return []string{"-"}, nil
+	}
+	var names, unmatched []string
+	for _, arg := range args {
+		if !strings.ContainsAny(arg, "*?[") {
+			names = append(names, arg)
+			continue
+		}
+		matches, err := filepath.Glob(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", arg, err)
+		}
+		if len(matches) == 0 {
+			unmatched = append(unmatched, arg)
+		}
+		names = append(names, matches...)
+	}
+	if len(unmatched) > 0 {
+		return names, fmt.Errorf("no files match %s", strings.Join(unmatched, ", "))
+	}
+	return names, nil
+}
+
+// count the words of the named input, reading stdin for "-"
+func count(ctx context.Context, name string, stdin io.Reader, opts wordcount.StreamOptions) (map[string]int, error) {
+	if name == "-" {
+		return wordcount.CountReader(ctx, stdin, opts)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return wordcount.CountReader(ctx, f, opts)
+}
+
+// name to show for an input
+func displayName(name string) string {
+	if name == "-" {
+		return "stdin"
+	}
+	return name
+}
+
+// the top words of counts (all if top is 0) ordered by count or word
+func newReport(file string, counts map[string]int, top int, sortBy string) report {
+	r := report{File: file, Unique: len(counts), Counts: wordcount.Top(counts, top)}
+	for _, n := range counts {
+		r.Words += n
+	}
+	if sortBy == "word" {
+		sort.Slice(r.Counts, func(i, j int) bool { return r.Counts[i].Word < r.Counts[j].Word })
+	}
+	return r
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goTour/wordcount"
+)
+
+// two small files in a temporary directory, returned with the directory
+func testFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, text := range map[string]string{
+		"a.txt": "Go is fun. Go, go!",
+		"b.txt": "fun gophers",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestRun(t *testing.T) {
+	dir := testFiles(t)
+	a, b := filepath.Join(dir, "a.txt"), filepath.Join(dir, "b.txt")
+	tests := []struct {
+		name   string
+		args   []string
+		stdin  string
+		status int
+		stdout string
+		stderr string // start of the error output
+	}{
+		{
+			name:  "stdin table",
+			stdin: "go is fun, Go is FUN",
+			stdout: "count  word\n" +
+				"    2  fun\n" +
+				"    2  go\n" +
+				"    2  is\n" +
+				"6 words, 3 different\n",
+		},
+		{
+			name: "merged files, sorted by word",
+			args: []string{"-sort", "word", a, b},
+			stdout: "count  word\n" +
+				"    2  fun\n" +
+				"    3  go\n" +
+				"    1  gophers\n" +
+				"    1  is\n" +
+				"7 words, 4 different\n",
+		},
+		{
+			name: "per-file table with a glob",
+			args: []string{"-per-file", "-top", "1", filepath.Join(dir, "*.txt")},
+			stdout: a + ":\n" +
+				"count  word\n" +
+				"    3  go\n" +
+				"5 words, 3 different\n" +
+				"\n" +
+				b + ":\n" +
+				"count  word\n" +
+				"    1  fun\n" +
+				"2 words, 2 different\n",
+		},
+		{
+			name:  "json",
+			args:  []string{"-format", "json", "-top", "2"},
+			stdin: "b a b",
+			stdout: `{
+  "words": 3,
+  "unique": 2,
+  "counts": [
+    {
+      "word": "b",
+      "count": 2
+    },
+    {
+      "word": "a",
+      "count": 1
+    }
+  ]
+}
+`,
+		},
+		{
+			name:   "per-file json",
+			args:   []string{"-format", "json", "-per-file", "-"},
+			stdin:  "a",
+			stdout: "[\n  {\n    \"file\": \"stdin\",\n    \"words\": 1,\n    \"unique\": 1,\n    \"counts\": [\n      {\n        \"word\": \"a\",\n        \"count\": 1\n      }\n    ]\n  }\n]\n",
+		},
+		{
+			name:   "csv",
+			args:   []string{"-format", "csv", "-keep-punct"},
+			stdin:  `say "hi", say`,
+			stdout: "word,count\nsay,2\n\"\"\"hi\"\",\",1\n",
+		},
+		{
+			name:   "per-file csv",
+			args:   []string{"-format", "csv", "-per-file", "-sort", "word", b, "-"},
+			stdin:  "fun",
+			stdout: "file,word,count\n" + b + ",fun,1\n" + b + ",gophers,1\nstdin,fun,1\n",
+		},
+		{
+			name:   "no words",
+			stdin:  " ,. \n",
+			status: exitEmpty,
+			stdout: "count  word\n0 words, 0 different\n",
+		},
+		{
+			name:   "stop words only",
+			args:   []string{"-stop"},
+			stdin:  "the and of",
+			status: exitEmpty,
+			stdout: "count  word\n0 words, 0 different\n",
+		},
+		{
+			// the readable file is still counted
+			name:   "missing file",
+			args:   []string{filepath.Join(dir, "missing.txt"), b},
+			status: exitUnreadable,
+			stdout: "count  word\n    1  fun\n    1  gophers\n2 words, 2 different\n",
+			stderr: "wordcount: " + filepath.Join(dir, "missing.txt") + ": open",
+		},
+		{
+			name:   "pattern without matches",
+			args:   []string{filepath.Join(dir, "*.md")},
+			status: exitUnreadable,
+			stdout: "count  word\n0 words, 0 different\n",
+			stderr: "wordcount: no files match " + filepath.Join(dir, "*.md"),
+		},
+		{name: "unknown format", args: []string{"-format", "xml"}, status: exitUsage, stderr: "usage: wordcount"},
+		{name: "unknown sort", args: []string{"-sort", "length"}, status: exitUsage, stderr: "usage: wordcount"},
+		{name: "unknown flag", args: []string{"-nope"}, status: exitUsage, stderr: "flag provided but not defined: -nope"},
+		{name: "help", args: []string{"-h"}, status: 0, stderr: "usage: wordcount"},
+	}
+	for _, tt := range tests {
+		var stdout, stderr strings.Builder
+		status := run(context.Background(), tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+		if status != tt.status {
+			t.Errorf("%s: exit status %d, want %d (stderr %q)", tt.name, status, tt.status, stderr.String())
+		}
+		if stdout.String() != tt.stdout {
+			t.Errorf("%s: wrote\n%s\nwant\n%s", tt.name, stdout.String(), tt.stdout)
+		}
+		if !strings.HasPrefix(stderr.String(), tt.stderr) || tt.stderr == "" && stderr.Len() > 0 {
+			t.Errorf("%s: stderr %q, want it to start with %q", tt.name, stderr.String(), tt.stderr)
+		}
+	}
+}
+
+func TestRunInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var stdout, stderr strings.Builder
+	if status := run(ctx, nil, strings.NewReader("go go"), &stdout, &stderr); status != exitInterrupt {
+		t.Errorf("interrupted run: exit status %d, want %d", status, exitInterrupt)
+	}
+	if stdout.Len() > 0 || stderr.String() != "wordcount: interrupted\n" {
+		t.Errorf("interrupted run wrote %q and %q", stdout.String(), stderr.String())
+	}
+}
+
+// writer that fails after n bytes
+type failingWriter struct {
+	n int
+}
+
+var errFull = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errFull
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestWriteErrors(t *testing.T) {
+	// a report long enough to fill the writers' buffers
+	r := report{Words: 5000, Unique: 5000}
+	for i := 0; i < 5000; i++ {
+		r.Counts = append(r.Counts, wordcount.WordFreq{Word: strings.Repeat("w", i%50+1), Count: 1})
+	}
+	for format, write := range writers {
+		for _, n := range []int{0, 10, 5000} {
+			if err := write(&failingWriter{n}, []report{r, r}, true); !errors.Is(err, errFull) {
+				t.Errorf("%s after %d bytes: %v, want %v", format, n, err, errFull)
+			}
+		}
+	}
+
+	// and the command reports it with exit status 1
+	var stderr strings.Builder
+	status := run(context.Background(), nil, strings.NewReader("go"), &failingWriter{0}, &stderr)
+	if status != exitUnreadable || !strings.Contains(stderr.String(), "disk full") {
+		t.Errorf("run writing to a full disk: exit status %d, stderr %q", status, stderr.String())
+	}
+}
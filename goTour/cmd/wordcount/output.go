@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// output formats by -format name; perFile is set when every report is for one input
+var writers = map[string]func(w io.Writer, reports []report, perFile bool) error{
+	"table": writeTable,
+	"json":  writeJSON,
+	"csv":   writeCSV,
+}
+
+// aligned count and word columns like uniq -c, under the file name in per-file mode
+func writeTable(w io.Writer, reports []report, perFile bool) error {
+	// bufio keeps the first write error, so one check at the end catches them all
+	bw := bufio.NewWriter(w)
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		if perFile {
+			fmt.Fprintf(bw, "%s:\n", r.File)
+		}
+		width := len("count")
+		for _, c := range r.Counts {
+			if n := len(strconv.Itoa(c.Count)); n > width {
+				width = n
+			}
+		}
+		fmt.Fprintf(bw, "%*s  %s\n", width, "count", "word")
+		for _, c := range r.Counts {
+			fmt.Fprintf(bw, "%*d  %s\n", width, c.Count, c.Word)
+		}
+		fmt.Fprintf(bw, "%d words, %d different\n", r.Words, r.Unique)
+	}
+	return bw.Flush()
+}
+
+// one indented JSON object, or an array of them in per-file mode
+func writeJSON(w io.Writer, reports []report, perFile bool) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if perFile {
+		return enc.Encode(reports)
+	}
+	return enc.Encode(reports[0])
+}
+
+// word,count records with a header, with the file in front in per-file mode
+func writeCSV(w io.Writer, reports []report, perFile bool) error {
+	cw := csv.NewWriter(w)
+	header := []string{"word", "count"}
+	if perFile {
+		header = append([]string{"file"}, header...)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range reports {
+		for _, c := range r.Counts {
+			record := []string{c.Word, strconv.Itoa(c.Count)}
+			if perFile {
+				record = append([]string{r.File}, record...)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,107 @@
+// Package complexsqrt computes square roots as complex128 values, so negative numbers
+// give numbers that can be used in further computation instead of the flow control
+// lesson's sqrt(x) + "i" strings.
+package complexsqrt
+
+import (
+	"math"
+	"math/cmplx" // complex numbers
+	"strconv"    // convert numbers to strings
+	"strings"
+)
+
+// Sqrt returns the principal square root of z (real part >= 0)
+func Sqrt(z complex128) complex128 {
+	return cmplx.Sqrt(z)
+}
+
+// SqrtFloat returns the principal square root of the real number x.
+// Negative numbers give a positive imaginary root, e.g. SqrtFloat(-4) = 2i.
+func SqrtFloat(x float64) complex128 {
+	return cmplx.Sqrt(complex(x, 0))
+}
+
+// Roots returns both square roots of z: the principal root and its negative
+func Roots(z complex128) (principal, secondary complex128) {
+	principal = Sqrt(z)
+	return principal, -principal
+}
+
+// complex number in polar form: R * e^(i*Theta)
+type Polar struct {
+	R     float64 // distance from the origin (abs value)
+	Theta float64 // angle from the positive real axis in radians, in [-Pi, Pi]
+}
+
+// ToPolar converts z from rectangular (a+bi) to polar form
+func ToPolar(z complex128) Polar {
+	r, theta := cmplx.Polar(z)
+	return Polar{r, theta}
+}
+
+// Rect converts p back to rectangular (a+bi) form
+func (p Polar) Rect() complex128 {
+	return cmplx.Rect(p.R, p.Theta)
+}
+
+// Sqrt returns the principal square root of p, staying in polar form
+func (p Polar) Sqrt() Polar {
+	return Polar{math.Sqrt(p.R), p.Theta / 2}
+}
+
+// how complex numbers are written out
+type Style int
+
+const (
+	Rectangular Style = iota // a+bi
+	PolarStyle               // r∠θ
+	Exponential              // re^(iθ)
+)
+
+// complex number formatting settings. The zero value writes a+bi with the shortest
+// representation of each part.
+type Format struct {
+	Style   Style
+	Digits  int  // significant digits, 0 for the shortest representation that reads back exactly
+	Degrees bool // write angles in degrees rather than radians
+}
+
+// Format writes z in the configured style
+func (f Format) Format(z complex128) string {
+	switch f.Style {
+	case PolarStyle:
+		p := ToPolar(z)
+		return f.float(p.R) + "∠" + f.angle(p.Theta)
+	case Exponential:
+		p := ToPolar(z)
+		return f.float(p.R) + "e^(" + f.angle(p.Theta) + "i)"
+	default:
+		re, im := f.float(real(z)), f.float(imag(z))
+		if !strings.HasPrefix(im, "-") && !strings.HasPrefix(im, "+") {
+			im = "+" + im
+		}
+		return re + im + "i"
+	}
+}
+
+// String formats z as a+bi with the shortest representation of each part
+func String(z complex128) string {
+	return Format{}.Format(z)
+}
+
+// format a real number with the configured number of digits
+func (f Format) float(x float64) string {
+	digits := f.Digits
+	if digits <= 0 {
+		digits = -1 // shortest representation
+	}
+	return strconv.FormatFloat(x, 'g', digits, 64)
+}
+
+// format an angle in radians or degrees
+func (f Format) angle(theta float64) string {
+	if f.Degrees {
+		return f.float(theta*180/math.Pi) + "°"
+	}
+	return f.float(theta)
+}
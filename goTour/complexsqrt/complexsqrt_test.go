@@ -0,0 +1,123 @@
+package complexsqrt
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+const eps = 1e-15
+
+func near(got, want complex128) bool {
+	return cmplx.Abs(got-want) <= eps*math.Max(1, cmplx.Abs(want))
+}
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		z, want complex128
+	}{
+		{0, 0},
+		{4, 2},
+		{-4, 2i},
+		{-1, 1i},
+		{2, math.Sqrt2},
+		{3 + 4i, 2 + 1i},
+		{-3 - 4i, 1 - 2i},
+		{2i, 1 + 1i},
+		{-2i, 1 - 1i},
+	}
+	for _, tt := range tests {
+		got := Sqrt(tt.z)
+		if !near(got, tt.want) {
+			t.Errorf("Sqrt(%v) = %v, want %v", tt.z, got, tt.want)
+		}
+		if real(got) < 0 {
+			t.Errorf("Sqrt(%v) = %v is not the principal root", tt.z, got)
+		}
+		if !near(got*got, tt.z) {
+			t.Errorf("Sqrt(%v)² = %v", tt.z, got*got)
+		}
+		p, s := Roots(tt.z)
+		if p != got || s != -got {
+			t.Errorf("Roots(%v) = %v, %v, want %v, %v", tt.z, p, s, got, -got)
+		}
+	}
+}
+
+func TestSqrtFloat(t *testing.T) {
+	tests := []struct {
+		x    float64
+		want complex128
+	}{
+		{0, 0},
+		{9, 3},
+		{-9, 3i},
+		{-2, complex(0, math.Sqrt2)},
+		{math.Inf(1), complex(math.Inf(1), 0)},
+	}
+	for _, tt := range tests {
+		if got := SqrtFloat(tt.x); got != tt.want && !near(got, tt.want) {
+			t.Errorf("SqrtFloat(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+	}
+	if got := SqrtFloat(math.NaN()); !cmplx.IsNaN(got) {
+		t.Errorf("SqrtFloat(NaN) = %v, want NaN", got)
+	}
+}
+
+func TestPolar(t *testing.T) {
+	tests := []struct {
+		z    complex128
+		want Polar
+	}{
+		{1, Polar{1, 0}},
+		{2i, Polar{2, math.Pi / 2}},
+		{-3, Polar{3, math.Pi}},
+		{-1i, Polar{1, -math.Pi / 2}},
+		{1 + 1i, Polar{math.Sqrt2, math.Pi / 4}},
+	}
+	for _, tt := range tests {
+		p := ToPolar(tt.z)
+		if math.Abs(p.R-tt.want.R) > eps || math.Abs(p.Theta-tt.want.Theta) > eps {
+			t.Errorf("ToPolar(%v) = %v, want %v", tt.z, p, tt.want)
+		}
+		if !near(p.Rect(), tt.z) {
+			t.Errorf("ToPolar(%v).Rect() = %v", tt.z, p.Rect())
+		}
+		// halving the angle in [-Pi, Pi] gives the principal root
+		if got := p.Sqrt().Rect(); !near(got, Sqrt(tt.z)) {
+			t.Errorf("ToPolar(%v).Sqrt() = %v, want %v", tt.z, got, Sqrt(tt.z))
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		f    Format
+		z    complex128
+		want string
+	}{
+		{Format{}, 3 + 4i, "3+4i"},
+		{Format{}, 1 - 2i, "1-2i"},
+		{Format{}, 2i, "0+2i"},
+		{Format{}, -0.5, "-0.5+0i"},
+		{Format{}, complex(1e21, 1e-7), "1e+21+1e-07i"},
+		{Format{}, complex(math.Inf(1), math.Inf(-1)), "+Inf-Infi"},
+		{Format{}, complex(0, math.NaN()), "0+NaNi"},
+		{Format{Digits: 3}, complex(1.0/3, -2.0/3), "0.333-0.667i"},
+		{Format{Style: PolarStyle}, -2, "2∠3.141592653589793"},
+		{Format{Style: PolarStyle, Degrees: true}, 2i, "2∠90°"},
+		{Format{Style: PolarStyle, Digits: 3}, 1 + 1i, "1.41∠0.785"},
+		{Format{Style: Exponential}, 1, "1e^(0i)"},
+		{Format{Style: Exponential, Degrees: true}, -1i, "1e^(-90°i)"},
+		{Format{Style: Exponential, Digits: 2, Degrees: true}, 3 + 4i, "5e^(53°i)"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.Format(tt.z); got != tt.want {
+			t.Errorf("%+v.Format(%v) = %q, want %q", tt.f, tt.z, got, tt.want)
+		}
+	}
+	if got := String(-4 - 1.5i); got != "-4-1.5i" {
+		t.Errorf("String(-4-1.5i) = %q, want -4-1.5i", got)
+	}
+}
@@ -1,24 +1,28 @@
 // concurrency
-package main
+package concurrency
 
 import (
 	"fmt"
+	"io"
 	"sync" // mutex: lock & unlock
 	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
 )
 
 // print out string
-func say(s string) {
+func say(w io.Writer, clk clock.Clock, s string) {
 	for i := 0; i < 5; i++ {
-		time.Sleep(100 * time.Millisecond)
-		fmt.Println(s)
+		clk.Sleep(100 * time.Millisecond)
+		fmt.Fprintln(w, s)
 	}
 }
 
 // start new goroutine (thread)
-func goroutines() {
-	go say("thread: world")
-	say("normal: hello")
+func Goroutines(w io.Writer, clk clock.Clock) {
+	go say(w, clk, "thread: world")
+	say(w, clk, "normal: hello")
 }
 
 // sum numbers in slice
@@ -32,7 +36,7 @@ func sum(s []int, c chan int) {
 
 // send/receive data (connect concurrent goroutines)
 // data flow in direction of arrow <-
-func channels() {
+func Channels(w io.Writer) {
 	s := []int{7, 2, 8, -9, 4, 0}
 
 	// unbuffered communication (synchronous communication)
@@ -42,7 +46,7 @@ func channels() {
 	go sum(s[len(c)/2:], c)
 
 	x, y := <-c, <-c // receive from c
-	fmt.Println(x, y, x+y)
+	fmt.Fprintln(w, x, y, x+y)
 
 	// buffered channels (for asynchronous communication)
 	// sends block when buffer is full, receives block when buffer is empty
@@ -50,8 +54,8 @@ func channels() {
 	ch <- 1
 	ch <- 2 // if not have 2 values in chan, then receive causes deadlock in receive
 	// ch <- 3 // causes deadlock since channel buffer is already full (send)
-	fmt.Println(<-ch)
-	fmt.Println(<-ch)
+	fmt.Fprintln(w, <-ch)
+	fmt.Fprintln(w, <-ch)
 }
 
 // fibonacci with channel
@@ -67,64 +71,65 @@ func fibonacci(n int, c chan int) {
 // sender can close channel to indicate that no more values will be sent
 // terminating a channel only necessary if receiver has to know no more values are coming (terminate range loop)
 // receiver can test if channel is closed through 2nd argument
-func rangeClose() {
+func RangeClose(w io.Writer) {
 	c := make(chan int, 10) // buffer limits number of goroutines launched
 	go fibonacci(cap(c), c) // run fibonacci until index 10
 	for i := range c {
-		fmt.Println(i)
+		fmt.Fprintln(w, i)
 	}
 	// TODO: how to run multiple concurrent processes
 }
 
 // fibonaci with select
-func fibonacciSelect(c, quit chan int) {
+func fibonacciSelect(w io.Writer, c, quit chan int) {
 	x, y := 0, 1
 	for {
 		select {
 		case c <- x:
 			x, y = y, x+y
 		case <-quit:
-			fmt.Println("quit")
+			fmt.Fprintln(w, "quit")
 			return
 		}
 	}
 }
 
 // TODO: confusion regarding this select situation
-func someFunc(c, quit chan int) {
+func someFunc(w io.Writer, c, quit chan int) {
 	for i := 0; i < 10; i++ {
-		fmt.Println(<-c)
+		fmt.Fprintln(w, <-c)
 	}
 	quit <- 0
 }
 
-// blocks until one of its cases can run & then executes that case
-func selectConcurrent() {
+// blocks until one of its cases can run & then executes that case; the ticks and sleeps
+// are on clk
+func SelectConcurrent(w io.Writer, clk clock.Clock) {
 	// TODO: confusion regarding this select situation
 	c := make(chan int)
 	quit := make(chan int)
 	// go func() {
 	// 	for i := 0; i < 10; i++ {
-	// 		fmt.Println(<-c)
+	// 		fmt.Fprintln(w, <-c)
 	// 	}
 	// 	quit <- 0
 	// }()
-	go someFunc(c, quit)
-	fibonacciSelect(c, quit)
+	go someFunc(w, c, quit)
+	fibonacciSelect(w, c, quit)
 
 	// default selection: run if no other case is ready (avoid blocking)
-	tick := time.Tick(100 * time.Millisecond)
-	boom := time.After(500 * time.Millisecond)
+	tick := clk.Tick(100 * time.Millisecond)
+	boom := clk.After(500 * time.Millisecond)
 	for {
 		select {
 		case <-tick:
-			fmt.Println("tick")
+			fmt.Fprintln(w, "tick")
 		case <-boom:
-			fmt.Println("BOOM!")
+			fmt.Fprintln(w, "BOOM!")
 			return
 		default:
-			fmt.Println("      .")
-			time.Sleep(50 * time.Millisecond)
+			fmt.Fprintln(w, "      .")
+			clk.Sleep(50 * time.Millisecond)
 		}
 	}
 }
@@ -150,25 +155,30 @@ func (c *SafeCounter) Value(key string) int {
 }
 
 // avoid conflicts by allowing only one goroutine to access a variable at a time
-func syncMutex() {
+func SyncMutex(w io.Writer, clk clock.Clock) {
 	c := SafeCounter{v: make(map[string]int)}
 	for i := 0; i < 1000; i++ {
 		go c.Inc("somekey")
 	}
 
-	fmt.Println(c.Value("somekey"))
-	time.Sleep(time.Second) // allows computation to complete by waiting
-	fmt.Println(c.Value("somekey"))
+	fmt.Fprintln(w, c.Value("somekey"))
+	clk.Sleep(time.Second) // allows computation to complete by waiting
+	fmt.Fprintln(w, c.Value("somekey"))
 
 }
 
 // goroutine = lightweight thread managed by Go runtime
-func main() {
-	fmt.Println("Concurrency with Goroutines")
-
-	// goroutines()
-	// channels()
-	// rangeClose()
-	// selectConcurrent()
-	syncMutex()
+func init() {
+	t := lesson.NewTopic(5, "concurrency", "Concurrency with Goroutines")
+	t.AddEnv("goroutines", "starting goroutines", func(env lesson.Env) {
+		Goroutines(env.Out, env.Clock)
+	})
+	t.Add("channels", "unbuffered and buffered channels", Channels)
+	t.Add("rangeClose", "range over and close channels", RangeClose)
+	t.AddEnv("selectConcurrent", "select and default selection", func(env lesson.Env) {
+		SelectConcurrent(env.Out, env.Clock)
+	})
+	t.AddEnv("syncMutex", "sync.Mutex guarded counter", func(env lesson.Env) {
+		SyncMutex(env.Out, env.Clock)
+	})
 }
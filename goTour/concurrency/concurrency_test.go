@@ -0,0 +1,101 @@
+package concurrency
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
+	"goTour/lesson/lessontest"
+)
+
+// the other lessons print in an order that changes between runs, as in the tour, and
+// are checked below
+func TestGolden(t *testing.T) {
+	lessontest.Golden(t, "concurrency", "goroutines", "channels", "selectConcurrent", "syncMutex")
+}
+
+func find(t *testing.T, name string) lesson.Lesson {
+	t.Helper()
+	l, ok := lesson.FindTopic("concurrency").Find(name)
+	if !ok {
+		t.Fatalf("no lesson %q", name)
+	}
+	return l
+}
+
+// the two goroutines print once every 100ms, in either order within each step
+func TestGoroutines(t *testing.T) {
+	c := clock.NewFake(lessontest.Time)
+	done := make(chan string)
+	go func() { done <- lessontest.Run(find(t, "goroutines"), c) }()
+	for i := 0; i < 5; i++ {
+		c.BlockUntil(2) // both goroutines are sleeping
+		c.Advance(100 * time.Millisecond)
+	}
+	lines := strings.Split(strings.TrimSuffix(<-done, "\n"), "\n")
+
+	// the lesson returns after its fifth hello, maybe before the fifth world
+	if len(lines) != 9 && len(lines) != 10 {
+		t.Fatalf("printed %d lines, want 9 or 10:\n%s", len(lines), strings.Join(lines, "\n"))
+	}
+	for i := 0; i+1 < len(lines); i += 2 {
+		if pair := lines[i] + ", " + lines[i+1]; pair != "normal: hello, thread: world" && pair != "thread: world, normal: hello" {
+			t.Errorf("step %d printed %s, want one hello and one world", i/2+1, pair)
+		}
+	}
+	if got, want := c.Now(), lessontest.Time.Add(500*time.Millisecond); !got.Equal(want) {
+		t.Errorf("clock at %v, want %v", got, want)
+	}
+}
+
+// the sums arrive in either order
+func TestChannels(t *testing.T) {
+	got := lessontest.Run(find(t, "channels"), clock.NewFake(lessontest.Time))
+	if got != "17 12 29\n1\n2\n" && got != "12 17 29\n1\n2\n" {
+		t.Errorf("printed %q", got)
+	}
+}
+
+// the fifth tick and the boom are due at the same time, so select may print the tick
+// before the BOOM! or not at all
+func TestSelectConcurrent(t *testing.T) {
+	c := clock.NewFake(lessontest.Time)
+	c.AutoAdvance()
+	got := lessontest.Run(find(t, "selectConcurrent"), c)
+
+	want := "0\n1\n1\n2\n3\n5\n8\n13\n21\n34\nquit\n" +
+		strings.Repeat("      .\n      .\ntick\n", 4) +
+		"      .\n      .\n"
+	if got != want+"BOOM!\n" && got != want+"tick\nBOOM!\n" {
+		t.Errorf("printed\n%s\nwant\n%s[tick]\nBOOM!", got, want)
+	}
+	if now, boom := c.Now(), lessontest.Time.Add(500*time.Millisecond); !now.Equal(boom) {
+		t.Errorf("BOOM! at %v, want %v", now, boom)
+	}
+}
+
+// the first count may be read before every goroutine has run; the second comes after
+// sleeping a second
+func TestSyncMutex(t *testing.T) {
+	c := clock.NewFake(lessontest.Time)
+	c.AutoAdvance()
+	got := lessontest.Run(find(t, "syncMutex"), c)
+
+	var counts []int
+	for _, line := range strings.Fields(got) {
+		n, err := strconv.Atoi(line)
+		if err != nil {
+			t.Fatalf("printed %q", got)
+		}
+		counts = append(counts, n)
+	}
+	if len(counts) != 2 || counts[0] < 0 || counts[0] > counts[1] || counts[1] > 1000 {
+		t.Errorf("printed counts %v, want two counts going up to at most 1000", counts)
+	}
+	if now, want := c.Now(), lessontest.Time.Add(time.Second); !now.Equal(want) {
+		t.Errorf("clock at %v after the lesson, want %v", now, want)
+	}
+}
@@ -0,0 +1,138 @@
+package constants
+
+import (
+	"go/constant"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	env := Default()
+	tests := []struct {
+		expr string
+		want string // ExactString of the value
+	}{
+		{"1 << 100", "1267650600228229401496703205376"},
+		{"Big >> 99", "2"},
+		{"Small", "2"},
+		{"10 / 4", "2"},
+		{"10 / 4.0", "5/2"},
+		{"7 % -3", "1"},
+		{"0xF0 &^ 0x30 | 1 ^ 2", "195"},
+		{"-5 + 12i", "(-5 + 12i)"},
+		{"5.0 << 2", "20"},
+		{"1 << 2.0", "4"},
+		{"'a' + 1", "98"},
+		{`"go" + "pher"`, `"gopher"`},
+		{"math.MaxInt64 + 1", "9223372036854775808"},
+		{"math.MaxUint8 == 255 && !false", "true"},
+		{"1i * 1i == -1", "true"},
+		{"^0", "-1"},
+	}
+	for _, tt := range tests {
+		v, err := Eval(tt.expr, env)
+		if err != nil || v.ExactString() != tt.want {
+			t.Errorf("Eval(%q) = %v, %v, want %s", tt.expr, v, err, tt.want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	env := Default()
+	tests := []struct {
+		expr string
+		want string // part of the error, as the compiler reports it
+	}{
+		// the integer operators reject float operands even with integer values
+		{"5.5 % 2", "operator % not defined on 5.5 (untyped float constant)"},
+		{"5.0 % 2", "operator % not defined on 5 (untyped float constant)"},
+		{"4 & 2.0", "operator & not defined on 2 (untyped float constant)"},
+		{"1.5 | 1", "operator | not defined"},
+		{"3 ^ 1i", "operator ^ not defined on (0 + 1i) (untyped complex constant)"},
+		{"6 &^ 2.0", "operator &^ not defined"},
+		{"1.5 << 2", "shifted operand 1.5 (untyped float constant) must be integer"},
+		{"1 >> 2.5", "invalid shift count 2.5 (untyped float constant)"},
+		{"1 << -1", "invalid shift count -1"},
+		{"1 << 100000", "too large"},
+		{"1 % 0", "division by zero"},
+		{"1 / 0.0", "division by zero"},
+		{`"a" + 1`, "mismatched types"},
+		{"1i < 2i", "mismatched types"},
+		{"true && 1", "operator && not defined"},
+		{"-true", "operator - not defined"},
+		{"Huge", "undefined: Huge"},
+		{"f(1)", "unsupported expression"},
+		{"1 +", "parse"},
+	}
+	for _, tt := range tests {
+		if v, err := Eval(tt.expr, env); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Eval(%q) = %v, %v, want an error containing %q", tt.expr, v, err, tt.want)
+		}
+	}
+}
+
+func TestExplore(t *testing.T) {
+	env := Default()
+	fits := func(r Report) map[string]TypeFit {
+		m := make(map[string]TypeFit)
+		for _, f := range r.Types {
+			m[f.Type] = f
+		}
+		return m
+	}
+
+	// the basics lesson: Big fits a float64 but overflows an int
+	r, err := Explore("Big", env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Kind != "untyped int" || r.Type != "int" || r.BitLen != 101 {
+		t.Errorf("Big is %s (%s), %d bits", r.Kind, r.Type, r.BitLen)
+	}
+	f := fits(r)
+	if f["int"].Fit != Overflow || f["int"].Converted != "0" || f["float64"].Fit != Exact || f["float64"].Converted != "1.2676506002282294e+30" {
+		t.Errorf("Big fits int as %+v and float64 as %+v", f["int"], f["float64"])
+	}
+
+	tests := []struct {
+		expr, typ string
+		fit       Fit
+		converted string
+	}{
+		{"255", "uint8", Exact, "255"},
+		{"256", "uint8", Overflow, "0"},
+		{"-129", "int8", Overflow, "127"},
+		{"2.5", "int", Truncated, "2"},
+		{"1.0 / 3", "float64", Lossy, "0.3333333333333333"},
+		{"1e39", "float32", Overflow, "+Inf"},
+		{"1 + 2i", "float64", Invalid, ""},
+		{"1 + 2i", "complex64", Exact, "(1+2i)"},
+		{"true", "int", Invalid, ""},
+	}
+	for _, tt := range tests {
+		r, err := Explore(tt.expr, env)
+		if err != nil {
+			t.Errorf("Explore(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := fits(r)[tt.typ]; got.Fit != tt.fit || got.Converted != tt.converted {
+			t.Errorf("%s as %s = %v %q, want %v %q", tt.expr, tt.typ, got.Fit, got.Converted, tt.fit, tt.converted)
+		}
+	}
+}
+
+func TestDefault(t *testing.T) {
+	env := Default()
+	for name, want := range map[string]string{
+		"math.MaxInt8":   "127",
+		"math.MinInt64":  "-9223372036854775808",
+		"math.MaxUint32": "4294967295",
+	} {
+		if v, ok := env[name]; !ok || v.ExactString() != want {
+			t.Errorf("%s = %v, want %s", name, v, want)
+		}
+	}
+	if f, _ := constant.Float64Val(env["math.MaxFloat64"]); f != 1.7976931348623157e308 {
+		t.Errorf("math.MaxFloat64 = %v", f)
+	}
+}
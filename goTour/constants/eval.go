@@ -0,0 +1,278 @@
+// Package constants evaluates Go constant expressions with arbitrary precision and reports
+// which built-in numeric types can hold the result, like the basics lesson's
+// Big = 1 << 100 which fits a float64 but overflows an int.
+package constants
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// named constants that can be used in expressions
+type Env map[string]constant.Value
+
+// constants from the basics lesson and package math, as written in their source
+var sources = [][2]string{
+	{"Pi", "3.14"},
+	{"Big", "1 << 100"},
+	{"Small", "Big >> 99"},
+
+	{"math.Pi", "3.14159265358979323846264338327950288419716939937510582097494459"},
+	{"math.E", "2.71828182845904523536028747135266249775724709369995957496696763"},
+	{"math.MaxFloat32", "0x1p127 * (1 + (1 - 0x1p-23))"},
+	{"math.SmallestNonzeroFloat32", "0x1p-126 * 0x1p-23"},
+	{"math.MaxFloat64", "0x1p1023 * (1 + (1 - 0x1p-52))"},
+	{"math.SmallestNonzeroFloat64", "0x1p-1022 * 0x1p-52"},
+}
+
+// Default returns the constants from the basics lesson (Pi, Big, Small) and the
+// numeric constants from the math package (math.Pi, math.MaxInt64, ...)
+func Default() Env {
+	env := Env{}
+	for _, src := range sources {
+		v, err := Eval(src[1], env)
+		if err != nil {
+			panic("constants: " + src[0] + ": " + err.Error())
+		}
+		env[src[0]] = v
+	}
+	for _, t := range intTypes {
+		switch {
+		case t.bits == 0: // int, uint and uintptr have no math constants
+		case t.signed:
+			env["math.MaxInt"+strconv.Itoa(t.bits)] = constant.Make(t.max())
+			env["math.MinInt"+strconv.Itoa(t.bits)] = constant.Make(t.min())
+		default:
+			env["math.MaxUint"+strconv.Itoa(t.bits)] = constant.Make(t.max())
+		}
+	}
+	return env
+}
+
+// Eval evaluates a Go constant expression such as "1 << 100", "Big >> 99" or "-5 + 12i".
+// Identifiers other than true and false are looked up in env (which may be nil).
+func Eval(expr string, env Env) (constant.Value, error) {
+	e, err := parser.ParseExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("parse %q: %w", expr, err)
+	}
+	return eval(e, env)
+}
+
+// evaluate an expression node
+func eval(e ast.Expr, env Env) (constant.Value, error) {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		v := constant.MakeFromLiteral(e.Value, e.Kind, 0)
+		if v.Kind() == constant.Unknown {
+			return nil, fmt.Errorf("invalid literal %s", e.Value)
+		}
+		return v, nil
+
+	case *ast.ParenExpr:
+		return eval(e.X, env)
+
+	case *ast.Ident:
+		switch e.Name {
+		case "true":
+			return constant.MakeBool(true), nil
+		case "false":
+			return constant.MakeBool(false), nil
+		}
+		return lookup(e.Name, env)
+
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported selector expression")
+		}
+		return lookup(pkg.Name+"."+e.Sel.Name, env)
+
+	case *ast.UnaryExpr:
+		x, err := eval(e.X, env)
+		if err != nil {
+			return nil, err
+		}
+		return unary(e.Op, x)
+
+	case *ast.BinaryExpr:
+		x, err := eval(e.X, env)
+		if err != nil {
+			return nil, err
+		}
+		y, err := eval(e.Y, env)
+		if err != nil {
+			return nil, err
+		}
+		return binary(e.Op, x, y)
+	}
+	return nil, fmt.Errorf("unsupported expression %T", e)
+}
+
+// look up a named constant
+func lookup(name string, env Env) (constant.Value, error) {
+	if v, ok := env[name]; ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("undefined: %s", name)
+}
+
+// evaluate a unary expression
+func unary(op token.Token, x constant.Value) (constant.Value, error) {
+	switch op {
+	case token.ADD, token.SUB:
+		if !isNumeric(x) {
+			return nil, fmt.Errorf("invalid operation: operator %s not defined on %s", op, x)
+		}
+	case token.XOR:
+		if x.Kind() != constant.Int {
+			return nil, fmt.Errorf("invalid operation: operator %s not defined on %s", op, x)
+		}
+	case token.NOT:
+		if x.Kind() != constant.Bool {
+			return nil, fmt.Errorf("invalid operation: operator %s not defined on %s", op, x)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", op)
+	}
+	// untyped constants have no size (prec 0)
+	return constant.UnaryOp(op, x, 0), nil
+}
+
+// evaluate a binary expression
+func binary(op token.Token, x, y constant.Value) (constant.Value, error) {
+	switch op {
+	case token.SHL, token.SHR:
+		return shift(op, x, y)
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		if !comparable(op, x, y) {
+			return nil, fmt.Errorf("invalid operation: %s %s %s (mismatched types)", x, op, y)
+		}
+		return constant.MakeBool(constant.Compare(x, op, y)), nil
+	case token.LAND, token.LOR:
+		if x.Kind() != constant.Bool || y.Kind() != constant.Bool {
+			return nil, fmt.Errorf("invalid operation: operator %s not defined on %s", op, x)
+		}
+	case token.ADD:
+		if !(isNumeric(x) && isNumeric(y)) && !(x.Kind() == constant.String && y.Kind() == constant.String) {
+			return nil, fmt.Errorf("invalid operation: %s + %s (mismatched types)", x, y)
+		}
+	case token.SUB, token.MUL, token.QUO:
+		if !isNumeric(x) || !isNumeric(y) {
+			return nil, fmt.Errorf("invalid operation: operator %s not defined on %s, %s", op, x, y)
+		}
+		if op == token.QUO {
+			if constant.Sign(y) == 0 {
+				return nil, fmt.Errorf("invalid operation: division by zero")
+			}
+			if x.Kind() == constant.Int && y.Kind() == constant.Int {
+				op = token.QUO_ASSIGN // integer division, like Go does for untyped integers
+			}
+		}
+	case token.REM, token.AND, token.OR, token.XOR, token.AND_NOT:
+		// untyped operands take the kind of the other if it is further along int, float,
+		// complex, so like the compiler, 5.0 % 2 is a float operation and not allowed
+		for _, v := range []constant.Value{x, y} {
+			if v.Kind() != constant.Int {
+				return nil, fmt.Errorf("invalid operation: operator %s not defined on %s (%s constant)", op, v, kindName(v))
+			}
+		}
+		if op == token.REM && constant.Sign(y) == 0 {
+			return nil, fmt.Errorf("invalid operation: division by zero")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator %s", op)
+	}
+	return constant.BinaryOp(x, op, y), nil
+}
+
+// evaluate x << y or x >> y
+func shift(op token.Token, x, y constant.Value) (constant.Value, error) {
+	// unlike the other integer operators, shifts take any constant with an integer
+	// value: 5.0 << 2 is 20, but 5.5 << 2 is an error
+	i := constant.ToInt(x)
+	if i.Kind() != constant.Int {
+		return nil, fmt.Errorf("invalid operation: shifted operand %s (%s constant) must be integer", x, kindName(x))
+	}
+	s, ok := constant.Uint64Val(constant.ToInt(y))
+	if !ok {
+		return nil, fmt.Errorf("invalid shift count %s (%s constant)", y, kindName(y))
+	}
+	// keep the results printable (the compiler gives up on constants over 512 bits anyway)
+	if op == token.SHL && s > 10000 {
+		return nil, fmt.Errorf("shift count %d too large", s)
+	}
+	return constant.Shift(i, op, uint(s)), nil
+}
+
+// check if x and y can be compared with op
+func comparable(op token.Token, x, y constant.Value) bool {
+	switch {
+	case isNumeric(x) && isNumeric(y):
+		// complex numbers can only be compared for equality
+		if x.Kind() == constant.Complex || y.Kind() == constant.Complex {
+			return op == token.EQL || op == token.NEQ
+		}
+		return true
+	case x.Kind() == constant.String && y.Kind() == constant.String:
+		return true
+	case x.Kind() == constant.Bool && y.Kind() == constant.Bool:
+		return op == token.EQL || op == token.NEQ
+	}
+	return false
+}
+
+// check if v is an int, float or complex constant
+func isNumeric(v constant.Value) bool {
+	switch v.Kind() {
+	case constant.Int, constant.Float, constant.Complex:
+		return true
+	}
+	return false
+}
+
+// name of the default type Go gives an untyped constant of v's kind
+func defaultType(v constant.Value) string {
+	switch v.Kind() {
+	case constant.Bool:
+		return "bool"
+	case constant.String:
+		return "string"
+	case constant.Int:
+		return "int"
+	case constant.Float:
+		return "float64"
+	case constant.Complex:
+		return "complex128"
+	}
+	return "unknown"
+}
+
+// untyped kind name as used in compiler messages
+func kindName(v constant.Value) string {
+	switch v.Kind() {
+	case constant.Int:
+		return "untyped int"
+	case constant.Float:
+		return "untyped float"
+	case constant.Complex:
+		return "untyped complex"
+	case constant.Bool:
+		return "untyped bool"
+	case constant.String:
+		return "untyped string"
+	}
+	return "unknown"
+}
+
+// exact representation of v: every digit for integers, Go's short form otherwise
+func exactString(v constant.Value) string {
+	if i := constant.ToInt(v); i.Kind() == constant.Int {
+		return i.ExactString()
+	}
+	return v.String()
+}
@@ -0,0 +1,249 @@
+package constants
+
+import (
+	"go/constant"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// how well a type can hold a constant
+type Fit int
+
+const (
+	Exact     Fit = iota // the value is represented exactly
+	Lossy                // allowed, but rounded (e.g. float64(1.0 / 3))
+	Overflow             // compile error: the value doesn't fit (e.g. needInt(Big))
+	Truncated            // compile error: integer type given a fractional value
+	Invalid              // compile error: wrong kind of value (complex with an imaginary part, bool, string)
+)
+
+// Representable reports whether the compiler accepts the conversion (Exact or Lossy)
+func (f Fit) Representable() bool {
+	return f == Exact || f == Lossy
+}
+
+// fit names as printed by the constants command
+func (f Fit) String() string {
+	switch f {
+	case Exact:
+		return "exact"
+	case Lossy:
+		return "lossy"
+	case Overflow:
+		return "overflow"
+	case Truncated:
+		return "truncated"
+	case Invalid:
+		return "invalid"
+	}
+	return "Fit(" + strconv.Itoa(int(f)) + ")"
+}
+
+// how a constant fits a single built-in type
+type TypeFit struct {
+	Type string
+	Fit  Fit
+	// value a conversion at run time would produce (wrapping around for integers,
+	// rounding to the nearest float or +-Inf for floats). Empty for Invalid.
+	Converted string
+}
+
+// constant value and how it fits every built-in numeric type
+type Report struct {
+	Expr   string
+	Value  constant.Value
+	Kind   string // untyped kind, e.g. "untyped int"
+	Type   string // default type, e.g. "int"
+	Exact  string // exact value (every digit for integers)
+	BitLen int    // bits needed for the integer part of the absolute value
+	Types  []TypeFit
+}
+
+// built-in integer type
+type intType struct {
+	name   string
+	bits   int // 0 for the platform sized int, uint and uintptr (64 bits here)
+	signed bool
+}
+
+// built-in integer types
+var intTypes = []intType{
+	{"int8", 8, true}, {"int16", 16, true}, {"int32", 32, true}, {"int64", 64, true}, {"int", 0, true},
+	{"uint8", 8, false}, {"uint16", 16, false}, {"uint32", 32, false}, {"uint64", 64, false}, {"uint", 0, false},
+	{"uintptr", 0, false},
+}
+
+// size in bits
+func (t intType) size() uint {
+	if t.bits == 0 {
+		return strconv.IntSize
+	}
+	return uint(t.bits)
+}
+
+// smallest value of the type
+func (t intType) min() *big.Int {
+	if !t.signed {
+		return new(big.Int)
+	}
+	return new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), t.size()-1))
+}
+
+// largest value of the type
+func (t intType) max() *big.Int {
+	n := t.size()
+	if t.signed {
+		n--
+	}
+	m := new(big.Int).Lsh(big.NewInt(1), n)
+	return m.Sub(m, big.NewInt(1))
+}
+
+// Explore evaluates expr and reports how its value fits each built-in numeric type
+func Explore(expr string, env Env) (Report, error) {
+	v, err := Eval(expr, env)
+	if err != nil {
+		return Report{}, err
+	}
+	return NewReport(expr, v), nil
+}
+
+// NewReport reports how v fits each built-in numeric type
+func NewReport(expr string, v constant.Value) Report {
+	r := Report{
+		Expr:  expr,
+		Value: v,
+		Kind:  kindName(v),
+		Type:  defaultType(v),
+		Exact: exactString(v),
+	}
+	if isNumeric(v) {
+		re := constant.Real(v)
+		if re.Kind() == constant.Int || re.Kind() == constant.Float {
+			r.BitLen = constant.BitLen(truncate(re))
+		}
+	}
+
+	for _, t := range intTypes {
+		r.Types = append(r.Types, intFit(t, v))
+	}
+	r.Types = append(r.Types,
+		floatFit("float32", 32, v),
+		floatFit("float64", 64, v),
+		complexFit("complex64", 32, v),
+		complexFit("complex128", 64, v),
+	)
+	return r
+}
+
+// how v fits integer type t
+func intFit(t intType, v constant.Value) TypeFit {
+	fit := TypeFit{Type: t.name}
+	if !isNumeric(v) || constant.Sign(constant.Imag(v)) != 0 {
+		fit.Fit = Invalid
+		return fit
+	}
+
+	re := constant.Real(v)
+	i := constant.ToInt(re)
+	if i.Kind() != constant.Int {
+		fit.Fit = Truncated
+		i = truncate(re)
+	} else if x := bigInt(i); x.Cmp(t.min()) < 0 || x.Cmp(t.max()) > 0 {
+		fit.Fit = Overflow
+	}
+	fit.Converted = wrap(bigInt(i), t).String()
+	return fit
+}
+
+// how v fits a float type with the given size
+func floatFit(name string, bits int, v constant.Value) TypeFit {
+	fit := TypeFit{Type: name}
+	if !isNumeric(v) || constant.Sign(constant.Imag(v)) != 0 {
+		fit.Fit = Invalid
+		return fit
+	}
+	fit.Fit, fit.Converted = roundFloat(constant.Real(v), bits)
+	return fit
+}
+
+// how v fits a complex type with the given part size
+func complexFit(name string, bits int, v constant.Value) TypeFit {
+	fit := TypeFit{Type: name}
+	if !isNumeric(v) {
+		fit.Fit = Invalid
+		return fit
+	}
+	reFit, re := roundFloat(constant.Real(v), bits)
+	imFit, im := roundFloat(constant.Imag(v), bits)
+	fit.Fit = reFit
+	if imFit > fit.Fit {
+		fit.Fit = imFit
+	}
+	if im[0] != '-' && im[0] != '+' {
+		im = "+" + im
+	}
+	fit.Converted = "(" + re + im + "i)"
+	return fit
+}
+
+// round a real constant to a float with the given size
+func roundFloat(v constant.Value, bits int) (Fit, string) {
+	var f float64
+	var exact bool
+	if bits == 32 {
+		var f32 float32
+		f32, exact = constant.Float32Val(v)
+		f = float64(f32)
+	} else {
+		f, exact = constant.Float64Val(v)
+	}
+
+	switch {
+	case math.IsInf(f, 0):
+		return Overflow, strconv.FormatFloat(f, 'g', -1, bits)
+	case exact:
+		return Exact, strconv.FormatFloat(f, 'g', -1, bits)
+	}
+	return Lossy, strconv.FormatFloat(f, 'g', -1, bits)
+}
+
+// integer part of a real constant (rounding towards zero)
+func truncate(v constant.Value) constant.Value {
+	if i := constant.ToInt(v); i.Kind() == constant.Int {
+		return i
+	}
+	f := new(big.Float)
+	switch x := constant.Val(v).(type) {
+	case *big.Float:
+		f.Set(x)
+	case *big.Rat:
+		f.SetRat(x)
+	case float64:
+		f.SetFloat64(x)
+	}
+	i, _ := f.Int(nil)
+	return constant.Make(i)
+}
+
+// value of an integer constant as a big.Int
+func bigInt(v constant.Value) *big.Int {
+	switch x := constant.Val(v).(type) {
+	case int64:
+		return big.NewInt(x)
+	case *big.Int:
+		return x
+	}
+	return new(big.Int)
+}
+
+// wrap x around to the size of t, like a conversion from a wider integer does
+func wrap(x *big.Int, t intType) *big.Int {
+	mod := new(big.Int).Lsh(big.NewInt(1), t.size())
+	w := new(big.Int).Mod(x, mod) // Mod is always >= 0
+	if t.signed && w.Cmp(t.max()) > 0 {
+		w.Sub(w, mod)
+	}
+	return w
+}
@@ -1,39 +1,43 @@
 // basic flow control statements: for, if, else, switch, defer
-package main
+package flowcontrol
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"runtime"
 	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
 )
 
 // basic for loop understanding
-func forLoops() {
+func ForLoops(w io.Writer) {
 	// basic for loop (go only has for loops)
 	sum := 0
 	for i := 0; i < 10; i++ {
 		sum += i
 	}
-	fmt.Println(sum)
+	fmt.Fprintln(w, sum)
 
 	// for can be like a while loop => optional init and post statements
 	sum = 1
 	for sum < 1000 {
 		sum += sum
 	}
-	fmt.Println(sum)
+	fmt.Fprintln(w, sum)
 
 	// infinite loop if exit condition not specified
 	// for {
 	// }
 
 	// for loops and functions
-	Sqrt(1)
+	Sqrt(w, 1)
 }
 
 // Newton's method: compute sqrt using loop through guesses
-func Sqrt(x float64) float64 {
+func Sqrt(w io.Writer, x float64) float64 {
 	z := x / 2
 	z_prev := -z
 	for i := 0; i < 10; i++ {
@@ -41,23 +45,23 @@ func Sqrt(x float64) float64 {
 
 		// break if value not changing
 		if z == z_prev {
-			fmt.Println("Exiting loop")
+			fmt.Fprintln(w, "Exiting loop")
 			break
 		}
 		z_prev = z
-		fmt.Println(z)
+		fmt.Fprintln(w, z)
 	}
-	fmt.Printf("The sqrt of %g is ~%g\n", x, z)
+	fmt.Fprintf(w, "The sqrt of %g is ~%g\n", x, z)
 	return z
 }
 
 // basic if/else understanding
-func ifElse() {
-	fmt.Println(sqrt(2), sqrt(-4))
+func IfElse(w io.Writer) {
+	fmt.Fprintln(w, sqrt(2), sqrt(-4))
 	// Both calls to pow return their results before the call to fmt.Println begins
-	fmt.Println(
-		pow(3, 2, 10),
-		pow(3, 3, 20),
+	fmt.Fprintln(w,
+		pow(w, 3, 2, 10),
+		pow(w, 3, 3, 20),
 	)
 }
 
@@ -71,93 +75,94 @@ func sqrt(x float64) string {
 	return fmt.Sprint(math.Sqrt(x))
 }
 
-// get biggest number between x^n or lim and return it
-func pow(x, n, lim float64) float64 {
+// get smallest number between x^n or lim and return it
+func pow(w io.Writer, x, n, lim float64) float64 {
 	// if statement can have short statement to execute before start of condition
 	if v := math.Pow(x, n); v < lim {
 		return v
 	} else {
 		// will print out before the numbers, since pow calls complete before it is printed out in if_else()
-		fmt.Printf("%g >= %g\n", v, lim)
+		fmt.Fprintf(w, "%g >= %g\n", v, lim)
 	}
 	return lim
 }
 
-// basic switch understanding
-func switchStatements() {
-	fmt.Println("Go runs on ")
+// basic switch understanding. The day and time of day come from c, in c's location.
+func SwitchStatements(w io.Writer, c clock.Clock) {
+	fmt.Fprintln(w, "Go runs on ")
 
 	// switch cases do not need to be integers
 	switch os := runtime.GOOS; os {
 	case "darwin":
-		fmt.Println("OS X.")
+		fmt.Fprintln(w, "OS X.")
 		// do not need break statement (automatically added)
 	case "linux":
-		fmt.Println("Linux.")
+		fmt.Fprintln(w, "Linux.")
 	default:
 		// freebsd, openbsd,
 		// plan9, windows...
-		fmt.Printf("%s.\n", os)
+		fmt.Fprintf(w, "%s.\n", os)
 	}
 
 	// switch cases do not need to be constants
-	fmt.Println("When's Saturday?")
-	today := time.Now().Weekday()
+	fmt.Fprintln(w, "When's Saturday?")
+	today := c.Now().Weekday()
 
 	switch time.Saturday {
 	case today:
-		fmt.Println("Today.")
+		fmt.Fprintln(w, "Today.")
 	case today + 1:
-		fmt.Println("Tomorrow.")
+		fmt.Fprintln(w, "Tomorrow.")
 	case today + 2:
-		fmt.Println("In two days.")
+		fmt.Fprintln(w, "In two days.")
 	default:
-		fmt.Println("Too far away.")
+		fmt.Fprintln(w, "Too far away.")
 
 	}
 
 	// switch with no conditions is the same as switch true (ideal for long if-then-else chains)
-	t := time.Now()
+	t := c.Now()
 	switch {
 	case t.Hour() < 12:
-		fmt.Println("Good morning.")
+		fmt.Fprintln(w, "Good morning.")
 	case t.Hour() < 17:
-		fmt.Println("Good afternoon.")
+		fmt.Fprintln(w, "Good afternoon.")
 	default:
-		fmt.Println("Good evening.")
+		fmt.Fprintln(w, "Good evening.")
 	}
 }
 
 // basic understanding of defer statements
-func deferStatements() {
+func DeferStatements(w io.Writer) {
 	// A defer statement defers the execution of a function until the surrounding function returns.
 	// The deferred call's arguments are evaluated immediately, but the function call is not executed
 	// until the surrounding function returns.
 
 	// will only execute after surrounding function (hello and stack_defer) is done
-	defer fmt.Println("world")
-	fmt.Println("hello")
+	defer fmt.Fprintln(w, "world")
+	fmt.Fprintln(w, "hello")
 
 	// stacking defers
-	stackDefer()
+	stackDefer(w)
 }
 
 // Deferred function calls are pushed onto a stack.
 // When a function returns, its deferred calls are executed in last-in-first-out order.
-func stackDefer() {
-	fmt.Println("counting")
+func stackDefer(w io.Writer) {
+	fmt.Fprintln(w, "counting")
 	for i := 0; i < 10; i++ {
 		// will print in revers order
-		defer fmt.Println(i)
+		defer fmt.Fprintln(w, i)
 	}
-	fmt.Println("done")
+	fmt.Fprintln(w, "done")
 }
 
-func main() {
-	fmt.Println("Flow control statements: for, if, else, switch, defer")
-	forLoops()
-	ifElse()
-	switchStatements()
-	deferStatements()
-
+func init() {
+	t := lesson.NewTopic(2, "flowcontrol", "Flow control statements: for, if, else, switch, defer")
+	t.Add("forLoops", "for loops and Newton's method square root", ForLoops)
+	t.Add("ifElse", "if/else with short statements", IfElse)
+	t.AddEnv("switchStatements", "switch on values, times and true", func(env lesson.Env) {
+		SwitchStatements(env.Out, env.Clock)
+	})
+	t.Add("deferStatements", "deferred and stacked deferred calls", DeferStatements)
 }
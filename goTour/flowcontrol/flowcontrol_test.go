@@ -0,0 +1,83 @@
+package flowcontrol
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"goTour/clock"
+	"goTour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+	// switchStatements prints the OS, its time switches are tested below
+	lessontest.Golden(t, "flowcontrol", "switchStatements")
+}
+
+func TestSwitchStatementsTime(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	newYork := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		saturday string
+		greeting string
+	}{
+		{"saturday morning", time.Date(2021, time.July, 10, 9, 0, 0, 0, time.UTC), "Today.", "Good morning."},
+		{"friday afternoon", time.Date(2021, time.July, 9, 12, 0, 0, 0, time.UTC), "Tomorrow.", "Good afternoon."},
+		{"thursday evening", time.Date(2021, time.July, 8, 17, 0, 0, 0, time.UTC), "In two days.", "Good evening."},
+		{"sunday", time.Date(2021, time.July, 11, 11, 59, 0, 0, time.UTC), "Too far away.", "Good morning."},
+		{"wednesday", time.Date(2021, time.July, 7, 16, 59, 0, 0, time.UTC), "Too far away.", "Good afternoon."},
+
+		// Friday 23:30 UTC is already Saturday morning in Tokyo and still Friday in New York
+		{"friday night utc", time.Date(2021, time.July, 9, 23, 30, 0, 0, time.UTC), "Tomorrow.", "Good evening."},
+		{"same instant in tokyo", time.Date(2021, time.July, 9, 23, 30, 0, 0, time.UTC).In(tokyo), "Today.", "Good morning."},
+		{"same instant in new york", time.Date(2021, time.July, 9, 23, 30, 0, 0, time.UTC).In(newYork), "Tomorrow.", "Good evening."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saturday, greeting := switchTimeLines(t, clock.NewFake(tt.now))
+			if saturday != tt.saturday || greeting != tt.greeting {
+				t.Errorf("at %v got %q, %q; want %q, %q", tt.now, saturday, greeting, tt.saturday, tt.greeting)
+			}
+		})
+	}
+}
+
+func TestSwitchStatementsAdvance(t *testing.T) {
+	// Thursday 8am: step through the week and the day
+	c := clock.NewFake(time.Date(2021, time.July, 8, 8, 0, 0, 0, time.UTC))
+	steps := []struct {
+		advance  time.Duration
+		saturday string
+		greeting string
+	}{
+		{0, "In two days.", "Good morning."},
+		{4 * time.Hour, "In two days.", "Good afternoon."},
+		{5 * time.Hour, "In two days.", "Good evening."},
+		{11 * time.Hour, "Tomorrow.", "Good morning."},
+		{24 * time.Hour, "Today.", "Good morning."},
+		{24 * time.Hour, "Too far away.", "Good morning."},
+	}
+	for _, s := range steps {
+		c.Advance(s.advance)
+		saturday, greeting := switchTimeLines(t, c)
+		if saturday != s.saturday || greeting != s.greeting {
+			t.Errorf("at %v got %q, %q; want %q, %q", c.Now(), saturday, greeting, s.saturday, s.greeting)
+		}
+	}
+}
+
+// run switchStatements and return its "When's Saturday?" answer and greeting
+func switchTimeLines(t *testing.T, c clock.Clock) (saturday, greeting string) {
+	t.Helper()
+	var out bytes.Buffer
+	SwitchStatements(&out, c)
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 5 || lines[2] != "When's Saturday?" {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	return lines[3], lines[4]
+}
@@ -0,0 +1,64 @@
+package functional
+
+// Compose returns g after f: a function x -> g(f(x))
+func Compose[A, B, C any](g func(B) C, f func(A) B) func(A) C {
+	return func(x A) C {
+		return g(f(x))
+	}
+}
+
+// Pipe returns a function that passes its argument through fs from left to right, so
+// Pipe(f, g, h)(x) is h(g(f(x))); with no functions it returns its argument
+func Pipe[T any](fs ...func(T) T) func(T) T {
+	return func(x T) T {
+		for _, f := range fs {
+			x = f(x)
+		}
+		return x
+	}
+}
+
+// Curry turns a function of two arguments into a chain of one argument functions:
+// Curry(hypot)(3)(4) is hypot(3, 4)
+func Curry[A, B, R any](f func(A, B) R) func(A) func(B) R {
+	return func(a A) func(B) R {
+		return func(b B) R {
+			return f(a, b)
+		}
+	}
+}
+
+// Uncurry undoes Curry
+func Uncurry[A, B, R any](f func(A) func(B) R) func(A, B) R {
+	return func(a A, b B) R {
+		return f(a)(b)
+	}
+}
+
+// Partial fixes the first argument of f: Partial(math.Pow, 2) is x -> 2^x
+func Partial[A, B, R any](f func(A, B) R, a A) func(B) R {
+	return func(b B) R {
+		return f(a, b)
+	}
+}
+
+// PartialRight fixes the second argument of f: PartialRight(math.Pow, 2) is x -> x²
+func PartialRight[A, B, R any](f func(A, B) R, b B) func(A) R {
+	return func(a A) R {
+		return f(a, b)
+	}
+}
+
+// pair of arguments, so a slice of them can be the dataset of a two argument function
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Spread turns a function of two arguments into one of a Pair: Map(points, Spread(hypot))
+// gives the hypotenuse of every pair
+func Spread[A, B, R any](f func(A, B) R) func(Pair[A, B]) R {
+	return func(p Pair[A, B]) R {
+		return f(p.First, p.Second)
+	}
+}
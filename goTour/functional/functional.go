@@ -0,0 +1,54 @@
+// Package functional takes the more types lesson's function values further: where
+// compute(fn) applies hypot or math.Pow once, to (3, 4), these helpers build new
+// functions out of them and apply them to whole datasets, held in slices or produced by
+// iter.Seq iterators.
+//
+//	square := PartialRight(math.Pow, 2)      // func(x) x²
+//	hyp := Curry(hypot)                      // hyp(3)(4) == 5
+//	lengths := Map(points, Spread(hypot))    // every point's distance from the origin
+//	total := Reduce(lengths, 0.0, func(sum, d float64) float64 { return sum + d })
+//	big := Filter(lengths, func(d float64) bool { return d > 10 })
+//
+// The slice functions (Map, Filter, Reduce, FlatMap) return new slices; the Seq ones
+// (MapSeq, FilterSeq, ReduceSeq, FlatMapSeq) are lazy and only call their functions as
+// the result is ranged over.
+package functional
+
+// Map returns f applied to every element of s
+func Map[E, R any](s []E, f func(E) R) []R {
+	out := make([]R, len(s))
+	for i, e := range s {
+		out[i] = f(e)
+	}
+	return out
+}
+
+// Filter returns the elements of s that keep reports true for, in order
+func Filter[S ~[]E, E any](s S, keep func(E) bool) S {
+	var out S
+	for _, e := range s {
+		if keep(e) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Reduce folds s into one value: f(...f(f(init, s[0]), s[1])..., s[n-1]), or init if s is
+// empty
+func Reduce[E, A any](s []E, init A, f func(A, E) A) A {
+	acc := init
+	for _, e := range s {
+		acc = f(acc, e)
+	}
+	return acc
+}
+
+// FlatMap returns the slices f makes of every element of s, joined in order
+func FlatMap[E, R any](s []E, f func(E) []R) []R {
+	var out []R
+	for _, e := range s {
+		out = append(out, f(e)...)
+	}
+	return out
+}
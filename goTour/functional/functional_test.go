@@ -0,0 +1,199 @@
+package functional
+
+import (
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// the more types lesson's function value
+func hypot(x, y float64) float64 {
+	return math.Sqrt(x*x + y*y)
+}
+
+func add(a, b float64) float64 {
+	return a + b
+}
+
+func TestSlices(t *testing.T) {
+	points := []Pair[float64, float64]{{3, 4}, {5, 12}, {8, 15}, {0, 0}}
+	lengths := Map(points, Spread(hypot))
+	if want := []float64{5, 13, 17, 0}; !reflect.DeepEqual(lengths, want) {
+		t.Errorf("Map(points, hypot) = %v, want %v", lengths, want)
+	}
+	if got := Reduce(lengths, 0.0, add); got != 35 {
+		t.Errorf("Reduce(lengths, +) = %v, want 35", got)
+	}
+	if got := Filter(lengths, func(d float64) bool { return d > 10 }); !reflect.DeepEqual(got, []float64{13, 17}) {
+		t.Errorf("Filter(lengths, > 10) = %v", got)
+	}
+	if got := Filter([]int{1, 3}, func(int) bool { return false }); got != nil {
+		t.Errorf("Filter keeping nothing = %#v, want nil", got)
+	}
+	words := FlatMap([]string{"Go is", "fun"}, strings.Fields)
+	if want := []string{"Go", "is", "fun"}; !reflect.DeepEqual(words, want) {
+		t.Errorf("FlatMap(strings.Fields) = %q, want %q", words, want)
+	}
+	if got := Reduce(nil, "init", func(a string, _ int) string { return a + "!" }); got != "init" {
+		t.Errorf("Reduce of nothing = %q, want init", got)
+	}
+}
+
+func TestSeq(t *testing.T) {
+	squares := MapSeq(slices.Values([]float64{1, 2, 3, 4}), PartialRight(math.Pow, 2))
+	if got := slices.Collect(squares); !reflect.DeepEqual(got, []float64{1, 4, 9, 16}) {
+		t.Errorf("MapSeq(x²) = %v", got)
+	}
+	even := FilterSeq(squares, func(x float64) bool { return math.Mod(x, 2) == 0 })
+	if got := ReduceSeq(even, 0.0, add); got != 20 {
+		t.Errorf("ReduceSeq of even squares = %v, want 20", got)
+	}
+	repeat := FlatMapSeq(slices.Values([]int{1, 2, 3}), func(n int) iter.Seq[string] {
+		return slices.Values(slices.Repeat([]string{strconv.Itoa(n)}, n))
+	})
+	if got := strings.Join(slices.Collect(repeat), ""); got != "122333" {
+		t.Errorf("FlatMapSeq = %q, want 122333", got)
+	}
+
+	// lazy: an endless sequence is only run as far as it is ranged over
+	calls := 0
+	naturals := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	var got []int
+	for n := range FlatMapSeq(FilterSeq(MapSeq(naturals, func(n int) int { calls++; return n * 3 }),
+		func(n int) bool { return n%2 == 1 }),
+		func(n int) iter.Seq[int] { return slices.Values([]int{n, -n}) }) {
+		got = append(got, n)
+		if len(got) == 5 {
+			break
+		}
+	}
+	if want := []int{3, -3, 9, -9, 15}; !reflect.DeepEqual(got, want) || calls != 6 {
+		t.Errorf("first 5 = %v after %d calls, want %v after 6", got, calls, want)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	// compute(fn) from the lesson is fn partly applied: compute(hypot) = hypot(3, 4)
+	compute := func(fn func(float64, float64) float64) float64 { return Partial(fn, 3)(4) }
+	if compute(hypot) != 5 || compute(math.Pow) != 81 {
+		t.Errorf("compute(hypot), compute(math.Pow) = %v, %v, want 5, 81", compute(hypot), compute(math.Pow))
+	}
+	if got := Curry(hypot)(5)(12); got != 13 {
+		t.Errorf("Curry(hypot)(5)(12) = %v", got)
+	}
+	if got := Uncurry(Curry(math.Pow))(2, 10); got != 1024 {
+		t.Errorf("Uncurry(Curry(math.Pow))(2, 10) = %v", got)
+	}
+	// 3 -> 9 -> 2^9 -> its length as text
+	f := Compose(func(x float64) int { return len(strconv.FormatFloat(x, 'f', -1, 64)) },
+		Compose(Partial(math.Pow, 2), PartialRight(math.Pow, 2)))
+	if got := f(3); got != 3 {
+		t.Errorf("Compose = %v, want 3 (512)", got)
+	}
+	double := func(x int) int { return 2 * x }
+	inc := func(x int) int { return x + 1 }
+	if got := Pipe(double, inc, double)(5); got != 22 {
+		t.Errorf("Pipe(double, inc, double)(5) = %d, want 22", got)
+	}
+	if got := Pipe[int]()(5); got != 5 {
+		t.Errorf("Pipe()(5) = %d, want 5", got)
+	}
+}
+
+func TestMemoize(t *testing.T) {
+	calls := 0
+	slowSqrt := func(x float64) float64 { calls++; return math.Sqrt(x) }
+	sqrt := Memoize(slowSqrt)
+	for _, x := range []float64{4, 9, 4, 4, 9} {
+		if sqrt(x) != math.Sqrt(x) {
+			t.Errorf("sqrt(%v) = %v", x, sqrt(x))
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Memoize called f %d times for 2 arguments", calls)
+	}
+
+	// a memoized recursive function only works each value out once
+	var fib func(int) int
+	fib = Memoize(func(n int) int {
+		if n < 2 {
+			return n
+		}
+		return fib(n-1) + fib(n-2)
+	})
+	if got := fib(90); got != 2880067194370816120 {
+		t.Errorf("fib(90) = %d", got)
+	}
+}
+
+func TestMemoizeSync(t *testing.T) {
+	var calls atomic.Int32
+	square := MemoizeSync(func(n int) int {
+		calls.Add(1)
+		return n * n
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				if got := square(n); got != n*n {
+					t.Errorf("square(%d) = %d", n, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 100 {
+		t.Errorf("MemoizeSync called f %d times for 100 arguments", calls.Load())
+	}
+
+	// a panic isn't remembered
+	fail := true
+	f := MemoizeSync(func(n int) int {
+		if fail {
+			panic("first call fails")
+		}
+		return n
+	})
+	func() {
+		defer func() { recover() }()
+		f(1)
+	}()
+	fail = false
+	if got := f(1); got != 1 {
+		t.Errorf("after a panic f(1) = %d, want 1", got)
+	}
+}
+
+func TestOnce(t *testing.T) {
+	var calls atomic.Int32
+	load := Once(func() []float64 {
+		calls.Add(1)
+		return []float64{3, 4}
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 20; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := hypot(load()[0], load()[1]); got != 5 {
+				t.Errorf("hypot(load()) = %v", got)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("Once called f %d times", calls.Load())
+	}
+}
@@ -0,0 +1,66 @@
+package functional
+
+import "sync"
+
+// Memoize returns f remembering its results: each argument is passed to f once and
+// later calls return the stored result. It is not safe for concurrent use; see
+// MemoizeSync.
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	cache := make(map[K]V)
+	return func(k K) V {
+		if v, ok := cache[k]; ok {
+			return v
+		}
+		v := f(k)
+		cache[k] = v
+		return v
+	}
+}
+
+// MemoizeSync is Memoize for use from many goroutines. Concurrent calls with the same
+// argument wait for one call of f instead of all calling it; calls with different
+// arguments don't wait for each other. If f panics, the argument isn't remembered and
+// the next call tries again.
+func MemoizeSync[K comparable, V any](f func(K) V) func(K) V {
+	type result struct {
+		once sync.Once
+		v    V
+		done bool // f returned instead of panicking
+	}
+	var mu sync.Mutex
+	cache := make(map[K]*result)
+	var memo func(K) V
+	memo = func(k K) V {
+		mu.Lock()
+		r, ok := cache[k]
+		if !ok {
+			r = new(result)
+			cache[k] = r
+		}
+		mu.Unlock()
+		r.once.Do(func() {
+			defer func() {
+				if !r.done {
+					mu.Lock()
+					delete(cache, k)
+					mu.Unlock()
+				}
+			}()
+			r.v = f(k)
+			r.done = true
+		})
+		if !r.done {
+			// waited for a call that panicked
+			return memo(k)
+		}
+		return r.v
+	}
+	return memo
+}
+
+// Once returns a function that calls f the first time it is called and returns that
+// result from then on, from any goroutine. If f panics, every call panics with the same
+// value.
+func Once[T any](f func() T) func() T {
+	return sync.OnceValue(f)
+}
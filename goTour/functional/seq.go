@@ -0,0 +1,48 @@
+package functional
+
+import "iter"
+
+// MapSeq returns a sequence of f applied to every value of seq
+func MapSeq[E, R any](seq iter.Seq[E], f func(E) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for e := range seq {
+			if !yield(f(e)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq returns a sequence of the values of seq that keep reports true for
+func FilterSeq[E any](seq iter.Seq[E], keep func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for e := range seq {
+			if keep(e) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// ReduceSeq folds every value of seq into one, like Reduce; seq must end
+func ReduceSeq[E, A any](seq iter.Seq[E], init A, f func(A, E) A) A {
+	acc := init
+	for e := range seq {
+		acc = f(acc, e)
+	}
+	return acc
+}
+
+// FlatMapSeq returns a sequence of the values of the sequences f makes of every value
+// of seq, one after the other
+func FlatMapSeq[E, R any](seq iter.Seq[E], f func(E) iter.Seq[R]) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for e := range seq {
+			for r := range f(e) {
+				if !yield(r) {
+					return
+				}
+			}
+		}
+	}
+}
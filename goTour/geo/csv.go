@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadCSV reads name,lat,long records, with or without a header line. Latitudes and
+// longitudes can be in any form ParseDMS reads, so 40.68433 and 40°41'04"N both work.
+// Malformed records are reported as a RecordErrors with their line numbers; the Registry
+// holds the others.
+func ReadCSV(in io.Reader) (Registry, error) {
+	cr := csv.NewReader(in)
+	cr.FieldsPerRecord = -1 // the wrong number is a RecordError, not the end of the file
+	cr.TrimLeadingSpace = true
+	cr.LazyQuotes = true // for the seconds in 40°41'04"N
+	rs := records{r: make(Registry)}
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			rs.errs = append(rs.errs, &RecordError{Line: pe.StartLine, Err: pe.Err})
+			continue
+		}
+		if err != nil {
+			return rs.r, err
+		}
+		line, _ := cr.FieldPos(0)
+		if first && isHeader(record) {
+			continue
+		}
+		if len(record) != 3 {
+			rs.add(line, 0, "", LatLong{}, fmt.Errorf("%w: %d fields, want name,lat,long", ErrInvalidRecord, len(record)))
+			continue
+		}
+		p, err := parseLatLong(record[1], record[2])
+		rs.add(line, 0, strings.TrimSpace(record[0]), p, err)
+	}
+	return rs.result()
+}
+
+// first line naming the columns, such as name,lat,long or name,latitude,longitude
+func isHeader(record []string) bool {
+	return len(record) == 3 && strings.EqualFold(strings.TrimSpace(record[0]), "name") &&
+		strings.HasPrefix(strings.ToLower(strings.TrimSpace(record[1])), "lat")
+}
+
+// location of a latitude and longitude field
+func parseLatLong(lat, long string) (LatLong, error) {
+	var p LatLong
+	var err error
+	if p.Lat, err = ParseDMS(lat, Latitude); err != nil {
+		return LatLong{}, err
+	}
+	if p.Long, err = ParseDMS(long, Longitude); err != nil {
+		return LatLong{}, err
+	}
+	return p, nil
+}
+
+// WriteCSV writes r as name,lat,long records in decimal degrees under a header line,
+// in order of name
+func (r Registry) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"name", "lat", "long"})
+	for _, name := range r.names() {
+		p := r[name]
+		cw.Write([]string{name, strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Long, 'f', -1, 64)})
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,189 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidDMS is returned for text that isn't a coordinate
+var ErrInvalidDMS = errors.New("geo: invalid coordinate")
+
+// which coordinate an angle is, deciding its hemisphere letters and range
+type Axis int
+
+const (
+	Latitude  Axis = iota // N or S, up to 90 degrees
+	Longitude             // E or W, up to 180 degrees
+)
+
+// "latitude" or "longitude"
+func (a Axis) String() string {
+	if a == Latitude {
+		return "latitude"
+	}
+	return "longitude"
+}
+
+// hemisphere letters for positive and negative angles
+func (a Axis) hemispheres() string {
+	if a == Latitude {
+		return "NS"
+	}
+	return "EW"
+}
+
+// FormatDMS formats an angle in degrees as degrees, minutes and whole seconds with its
+// hemisphere, e.g. 40.68433 as a latitude gives 40°41'04"N
+func FormatDMS(deg float64, axis Axis) string {
+	hemi := axis.hemispheres()[0]
+	if deg < 0 {
+		hemi, deg = axis.hemispheres()[1], -deg
+	}
+	secs := int64(math.Round(deg * 3600))
+	if secs == 0 {
+		hemi = axis.hemispheres()[0]
+	}
+	return fmt.Sprintf("%d°%02d'%02d\"%c", secs/3600, secs/60%60, secs%60, hemi)
+}
+
+// DMS formats p as degrees, minutes and seconds, e.g. 40°41'04"N 74°23'59"W
+func (p LatLong) DMS() string {
+	return FormatDMS(p.Lat, Latitude) + " " + FormatDMS(p.Long, Longitude)
+}
+
+// ParseDMS reads an angle written in degrees, minutes and seconds, or decimal degrees:
+// 40°41'03"N, N40°41'03", 40° 41.05' N, 40d41m03s N, 40 41 03 and -40.68433 all work. The
+// hemisphere letter (N, S, E or W) goes at either end; without one, a minus sign means
+// south or west. Minutes and seconds may be left out and ′ and ″ can stand for ' and ".
+func ParseDMS(s string, axis Axis) (float64, error) {
+	orig := s
+	bad := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w %q: %s", ErrInvalidDMS, orig, fmt.Sprintf(format, args...))
+	}
+
+	s = strings.TrimSpace(s)
+	sign, hemi := 1.0, false
+	if s != "" {
+		// hemisphere letter at the start or the end
+		for _, end := range []int{0, len(s) - 1} {
+			c := s[end]
+			i := strings.IndexByte(axis.hemispheres(), c)
+			if i < 0 {
+				if strings.IndexByte("NSEW", c) >= 0 {
+					return 0, bad("%c is not a hemisphere of a %s", c, axis)
+				}
+				continue
+			}
+			if i == 1 {
+				sign = -1
+			}
+			hemi = true
+			s = strings.TrimSpace(s[:end] + s[end+1:])
+			break
+		}
+	}
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		if hemi {
+			return 0, bad("both a sign and a hemisphere")
+		}
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+
+	var parts [3]float64 // degrees, minutes, seconds
+	next := 0            // part a number without a unit is
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.') {
+			i++
+		}
+		if i == 0 {
+			r, _ := utf8.DecodeRuneInString(s)
+			return 0, bad("unexpected %q", r)
+		}
+		v, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil {
+			return 0, bad("%q is not a number", s[:i])
+		}
+		s = s[i:]
+
+		part := next
+		unit, size := utf8.DecodeRuneInString(s)
+		switch {
+		case strings.HasPrefix(s, "''"):
+			part, size = 2, 2
+		case strings.ContainsRune("°ºd", unit):
+			part = 0
+		case strings.ContainsRune("'′’m", unit):
+			part = 1
+		case strings.ContainsRune("\"″”s", unit):
+			part = 2
+		default:
+			size = 0
+		}
+		if part < next || part > 2 {
+			return 0, bad("degrees, minutes and seconds out of order")
+		}
+		parts[part], next = v, part+1
+		s = s[size:]
+	}
+	if next == 0 {
+		return 0, bad("no degrees")
+	}
+	if parts[1] >= 60 || parts[2] >= 60 {
+		return 0, bad("minutes and seconds go up to 60")
+	}
+
+	deg := sign * (parts[0] + parts[1]/60 + parts[2]/3600)
+	p := LatLong{Long: deg}
+	if axis == Latitude {
+		p = LatLong{Lat: deg}
+	}
+	if err := p.Validate(); err != nil {
+		return 0, err
+	}
+	return deg, nil
+}
+
+// ParseLatLong reads a latitude and a longitude in any form ParseDMS reads, separated
+// by a comma or white space, e.g. `40°41'03"N 74°23'59"W` or "40.68433, -74.39967"
+func ParseLatLong(s string) (LatLong, error) {
+	lat, long, ok := splitLatLong(strings.TrimSpace(s))
+	if !ok {
+		return LatLong{}, fmt.Errorf("%w %q: want a latitude and a longitude", ErrInvalidDMS, s)
+	}
+	var p LatLong
+	var err error
+	if p.Lat, err = ParseDMS(lat, Latitude); err != nil {
+		return LatLong{}, err
+	}
+	if p.Long, err = ParseDMS(long, Longitude); err != nil {
+		return LatLong{}, err
+	}
+	return p, nil
+}
+
+// split s into its latitude and longitude: at a comma, after the latitude's hemisphere
+// (40N 74W), before the longitude's (N40 W74), or at the only white space (40.7 -74.4)
+func splitLatLong(s string) (string, string, bool) {
+	if i := strings.IndexByte(s, ','); i >= 0 {
+		return s[:i], s[i+1:], true
+	}
+	if i := strings.IndexAny(s, "NS"); i > 0 {
+		return s[:i+1], s[i+1:], true
+	} else if i == 0 {
+		if j := strings.IndexAny(s, "EW"); j > 0 {
+			return s[:j], s[j:], true
+		}
+	}
+	if f := strings.Fields(s); len(f) == 2 {
+		return f[0], f[1], true
+	}
+	return "", "", false
+}
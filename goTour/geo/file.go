@@ -0,0 +1,151 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidRecord is wrapped by errors for records that aren't a named location
+var ErrInvalidRecord = errors.New("geo: invalid record")
+
+// ErrUnknownFormat is returned for file names without a .csv, .geojson or .json extension
+var ErrUnknownFormat = errors.New("geo: unknown file format")
+
+// RecordError is a malformed record in a file of locations: a CSV line or a GeoJSON
+// feature, both numbered from 1
+type RecordError struct {
+	Line    int // CSV line, 0 for GeoJSON
+	Feature int // GeoJSON feature, 0 for CSV
+	Err     error
+}
+
+func (e *RecordError) Error() string {
+	if e.Feature > 0 {
+		return fmt.Sprintf("feature %d: %v", e.Feature, e.Err)
+	}
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// RecordErrors is every malformed record found reading a file, in file order; the
+// locations of the other records are still read
+type RecordErrors []*RecordError
+
+func (e RecordErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// locations read so far and the malformed records among them
+type records struct {
+	r    Registry
+	errs RecordErrors
+}
+
+// add p under name, or a RecordError for line or feature if it is invalid or the name
+// is taken
+func (rs *records) add(line, feature int, name string, p LatLong, err error) {
+	if err == nil {
+		switch _, dup := rs.r[name]; {
+		case name == "":
+			err = fmt.Errorf("%w: no name", ErrInvalidRecord)
+		case dup:
+			err = fmt.Errorf("%w: %q appears more than once", ErrInvalidRecord, name)
+		default:
+			err = p.Validate()
+		}
+	}
+	if err != nil {
+		rs.errs = append(rs.errs, &RecordError{line, feature, err})
+		return
+	}
+	rs.r[name] = p
+}
+
+// the locations read, and the record errors if there were any
+func (rs *records) result() (Registry, error) {
+	if len(rs.errs) > 0 {
+		return rs.r, rs.errs
+	}
+	return rs.r, nil
+}
+
+// names of r in alphabetical order, so files are written the same every time
+func (r Registry) names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadFile reads locations from a CSV (.csv) or GeoJSON (.geojson or .json) file, e.g.
+// the maps lesson's map[string]Vertex2 literal. The Registry holds the valid records even
+// when the error is a RecordErrors.
+func ReadFile(name string) (Registry, error) {
+	read, err := format(name)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := read.read(f)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", name, err)
+	}
+	return r, err
+}
+
+// WriteFile writes the locations of r to a CSV or GeoJSON file, chosen by the extension
+// of name as in ReadFile
+func (r Registry) WriteFile(name string) error {
+	write, err := format(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write.write(r, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readers and writers by file extension
+var formats = map[string]fileFormat{
+	".csv":     {ReadCSV, Registry.WriteCSV},
+	".geojson": {ReadGeoJSON, Registry.WriteGeoJSON},
+	".json":    {ReadGeoJSON, Registry.WriteGeoJSON},
+}
+
+type fileFormat struct {
+	read  func(r io.Reader) (Registry, error)
+	write func(r Registry, w io.Writer) error
+}
+
+// format of file name
+func format(name string) (fileFormat, error) {
+	f, ok := formats[strings.ToLower(filepath.Ext(name))]
+	if !ok {
+		return fileFormat{}, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+	return f, nil
+}
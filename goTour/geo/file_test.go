@@ -0,0 +1,122 @@
+package geo
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// the maps lesson's map literal, as kept in testdata
+var lessonMap = Registry{"Bell Labs": bellLabs, "Google": google}
+
+func TestReadFile(t *testing.T) {
+	for _, name := range []string{"testdata/locations.csv", "testdata/locations.geojson"} {
+		r, err := ReadFile(name)
+		if err != nil || !reflect.DeepEqual(r, lessonMap) {
+			t.Errorf("ReadFile(%s) = %v, %v, want %v", name, r, err, lessonMap)
+		}
+	}
+	if _, err := ReadFile("testdata/locations.kml"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("ReadFile of a .kml file returned %v, want %v", err, ErrUnknownFormat)
+	}
+}
+
+func TestWriteFileRoundTrip(t *testing.T) {
+	r := Registry{
+		"Bell Labs":      bellLabs,
+		"Google":         google,
+		"Null Island":    {0, 0},
+		"South Pole":     {-90, 0},
+		`"Quoted", name`: {-33.8568, 151.2153},
+	}
+	for _, ext := range []string{".csv", ".geojson", ".json"} {
+		name := filepath.Join(t.TempDir(), "locations"+ext)
+		if err := r.WriteFile(name); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadFile(name)
+		if err != nil || !reflect.DeepEqual(got, r) {
+			t.Errorf("%s round trip = %v, %v, want %v", ext, got, err, r)
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var b strings.Builder
+	if err := lessonMap.WriteCSV(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := "name,lat,long\nBell Labs,40.68433,-74.39967\nGoogle,37.42202,-122.08408\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV wrote\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+// line or feature numbers of the errors in err
+func recordNumbers(t *testing.T, err error) []int {
+	t.Helper()
+	var errs RecordErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error %v is not a RecordErrors", err)
+	}
+	var n []int
+	for _, e := range errs {
+		n = append(n, e.Line+e.Feature)
+	}
+	return n
+}
+
+func TestReadCSVMalformed(t *testing.T) {
+	in := `Bell Labs,40°41'04"N,74°23'59"W
+Google,37.42202
+Nowhere,100,0
+,1,2
+
+Times Square, 40.758, -73.9855
+Bell Labs,40.68433,-74.39967
+"Broken,1,2
+`
+	r, err := ReadCSV(strings.NewReader(in))
+	if got, want := recordNumbers(t, err), []int{2, 3, 4, 7, 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadCSV reported lines %v, want %v: %v", got, want, err)
+	}
+	if !errors.Is(err.(RecordErrors)[1], ErrInvalidLatitude) {
+		t.Errorf("latitude 100 reported as %v", err.(RecordErrors)[1])
+	}
+	if len(r) != 2 || !near(r["Bell Labs"].Lat, bellLabs.Lat, 1.0/3600) || r["Times Square"] != (LatLong{40.758, -73.9855}) {
+		t.Errorf("ReadCSV kept %v", r)
+	}
+	if got := err.Error(); !strings.HasPrefix(got, "line 2: geo: invalid record: 2 fields") {
+		t.Errorf("error message %q", got)
+	}
+}
+
+func TestReadGeoJSONMalformed(t *testing.T) {
+	in := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-74.39967, 40.68433]}, "properties": {"name": "Bell Labs"}},
+		{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}, "properties": {"name": "Road"}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0]}, "properties": {"name": "Half"}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [0, 0]}, "properties": {}},
+		{"type": "Feature", "geometry": {"type": "Point", "coordinates": [40.68, 95]}, "properties": {"name": "Too far north"}},
+		{"type": "Feature", "geometry": null, "properties": {"name": "Nothing"}},
+		"not a feature"
+	]}`
+	r, err := ReadGeoJSON(strings.NewReader(in))
+	if got, want := recordNumbers(t, err), []int{2, 3, 4, 5, 6, 7}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadGeoJSON reported features %v, want %v: %v", got, want, err)
+	}
+	if !errors.Is(err.(RecordErrors)[3], ErrInvalidLatitude) {
+		t.Errorf("latitude 95 reported as %v", err.(RecordErrors)[3])
+	}
+	if len(r) != 1 || r["Bell Labs"] != bellLabs {
+		t.Errorf("ReadGeoJSON kept %v", r)
+	}
+
+	for _, in := range []string{`{"type": "Feature"}`, `[1, 2]`, `{"type": "FeatureCollection"`} {
+		if _, err := ReadGeoJSON(strings.NewReader(in)); err == nil {
+			t.Errorf("ReadGeoJSON(%s) returned no error", in)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Package geo computes with the maps lesson's {Lat, Long} locations: great circle
+// (haversine) and ellipsoidal (Vincenty) distances, bearings, midpoints and destination
+// points, validation, degrees-minutes-seconds text such as 40°41'03"N, and a registry
+// of named locations that finds the nearest one. An Index answers radius, nearest and
+// bounding box queries over many locations without measuring the distance to them all.
+// ReadFile and WriteFile keep named locations in CSV and GeoJSON files.
+//
+// Latitudes and longitudes are in degrees (north and east positive), bearings in degrees
+// clockwise from north and distances in metres.
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// mean radius of the Earth in metres, used by the spherical formulas
+const EarthRadius = 6371008.8
+
+// WGS 84 ellipsoid, used by Vincenty
+const (
+	wgs84A = 6378137.0         // semi-major axis in metres
+	wgs84F = 1 / 298.257223563 // flattening
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+// errors for invalid coordinates and failed computations
+var (
+	ErrInvalidLatitude  = errors.New("geo: latitude is not between -90 and 90")
+	ErrInvalidLongitude = errors.New("geo: longitude is not between -180 and 180")
+	ErrNoConvergence    = errors.New("geo: Vincenty's formula did not converge")
+)
+
+// location on Earth, the maps lesson's Vertex2
+type LatLong struct {
+	Lat, Long float64
+}
+
+// Validate checks the latitude is in [-90, 90] and the longitude in [-180, 180]
+func (p LatLong) Validate() error {
+	switch {
+	case !(p.Lat >= -90 && p.Lat <= 90): // also catches NaN
+		return fmt.Errorf("%w: %v", ErrInvalidLatitude, p.Lat)
+	case !(p.Long >= -180 && p.Long <= 180):
+		return fmt.Errorf("%w: %v", ErrInvalidLongitude, p.Long)
+	}
+	return nil
+}
+
+// radians of p's latitude and longitude
+func (p LatLong) radians() (float64, float64) {
+	return p.Lat * math.Pi / 180, p.Long * math.Pi / 180
+}
+
+// location at latitude and longitude in radians
+func fromRadians(lat, long float64) LatLong {
+	return LatLong{lat * 180 / math.Pi, normalizeLong(long * 180 / math.Pi)}
+}
+
+// longitude in degrees wrapped into [-180, 180)
+func normalizeLong(long float64) float64 {
+	long = math.Mod(long+180, 360)
+	if long < 0 {
+		long += 360
+	}
+	return long - 180
+}
+
+// Haversine returns the great circle distance between p and q on a sphere of
+// EarthRadius. It is within about 0.5% of the distance on the real (ellipsoidal) Earth.
+func Haversine(p, q LatLong) float64 {
+	lat1, long1 := p.radians()
+	lat2, long2 := q.radians()
+	sinLat, sinLong := math.Sin((lat2-lat1)/2), math.Sin((long2-long1)/2)
+	h := sinLat*sinLat + math.Cos(lat1)*math.Cos(lat2)*sinLong*sinLong
+	return 2 * EarthRadius * math.Asin(math.Sqrt(math.Min(h, 1)))
+}
+
+// Vincenty returns the distance between p and q on the WGS 84 ellipsoid using Vincenty's
+// inverse formula, accurate to a millimetre or so. It returns ErrNoConvergence for some
+// nearly antipodal points; Haversine is a fallback for those.
+func Vincenty(p, q LatLong) (float64, error) {
+	lat1, long1 := p.radians()
+	lat2, long2 := q.radians()
+	l := long2 - long1
+	// reduced latitudes
+	u1, u2 := math.Atan((1-wgs84F)*math.Tan(lat1)), math.Atan((1-wgs84F)*math.Tan(lat2))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	lambda := l
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		a, b := cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda
+		sinSigma := math.Sqrt(a*a + b*b)
+		if sinSigma == 0 {
+			return 0, nil // same point
+		}
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0 // both points on the equator
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		prev := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+		if math.Abs(lambda-prev) > 1e-12 {
+			continue
+		}
+
+		uu := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+		bigA := 1 + uu/16384*(4096+uu*(-768+uu*(320-175*uu)))
+		bigB := uu / 1024 * (256 + uu*(-128+uu*(74-47*uu)))
+		deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+			bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+		return wgs84B * bigA * (sigma - deltaSigma), nil
+	}
+	return 0, ErrNoConvergence
+}
+
+// Bearing returns the initial bearing from p to q along the great circle, in [0, 360)
+func Bearing(p, q LatLong) float64 {
+	lat1, long1 := p.radians()
+	lat2, long2 := q.radians()
+	dLong := long2 - long1
+	y := math.Sin(dLong) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLong)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// Midpoint returns the point halfway between p and q along the great circle
+func Midpoint(p, q LatLong) LatLong {
+	lat1, long1 := p.radians()
+	lat2, long2 := q.radians()
+	dLong := long2 - long1
+	bx, by := math.Cos(lat2)*math.Cos(dLong), math.Cos(lat2)*math.Sin(dLong)
+	lat := math.Atan2(math.Sin(lat1)+math.Sin(lat2), math.Hypot(math.Cos(lat1)+bx, by))
+	return fromRadians(lat, long1+math.Atan2(by, math.Cos(lat1)+bx))
+}
+
+// Destination returns the point reached by travelling distance metres from p along the
+// great circle starting at the given bearing
+func Destination(p LatLong, bearing, distance float64) LatLong {
+	lat1, long1 := p.radians()
+	theta := bearing * math.Pi / 180
+	delta := distance / EarthRadius // angular distance
+	sinLat := math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta)
+	lat2 := math.Asin(sinLat)
+	long2 := long1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*sinLat)
+	return fromRadians(lat2, long2)
+}
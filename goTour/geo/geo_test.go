@@ -0,0 +1,195 @@
+package geo
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// locations from the maps lesson
+var (
+	bellLabs = LatLong{40.68433, -74.39967}
+	google   = LatLong{37.42202, -122.08408}
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestDistances(t *testing.T) {
+	// Vincenty's own example: Flinders Peak to Buninyong, 54972.271 m
+	flinders := LatLong{-(37 + 57/60.0 + 3.72030/3600), 144 + 25/60.0 + 29.52440/3600}
+	buninyong := LatLong{-(37 + 39/60.0 + 10.15610/3600), 143 + 55/60.0 + 35.38390/3600}
+	d, err := Vincenty(flinders, buninyong)
+	if err != nil || !near(d, 54972.271, 0.001) {
+		t.Errorf("Vincenty(Flinders Peak, Buninyong) = %.4f, %v, want 54972.271", d, err)
+	}
+
+	if d := Haversine(bellLabs, google); !near(d, 4082982, 1) {
+		t.Errorf("Haversine(Bell Labs, Google) = %.0f m, want 4082982", d)
+	}
+	v, _ := Vincenty(bellLabs, google)
+	if math.Abs(v-Haversine(bellLabs, google))/v > 0.005 {
+		t.Errorf("Vincenty %.0f and Haversine %.0f differ by more than 0.5%%", v, Haversine(bellLabs, google))
+	}
+
+	if d, err := Vincenty(google, google); d != 0 || err != nil {
+		t.Errorf("Vincenty(p, p) = %v, %v, want 0", d, err)
+	}
+	// antipodal points on the equator are the classic failure
+	if _, err := Vincenty(LatLong{0, 0}, LatLong{0.5, 179.7}); !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Vincenty of nearly antipodal points returned %v, want %v", err, ErrNoConvergence)
+	}
+}
+
+func TestBearingMidpointDestination(t *testing.T) {
+	tests := []struct {
+		p, q    LatLong
+		bearing float64
+	}{
+		{LatLong{0, 0}, LatLong{10, 0}, 0},
+		{LatLong{0, 0}, LatLong{0, 10}, 90},
+		{LatLong{0, 0}, LatLong{-10, 0}, 180},
+		{LatLong{0, 0}, LatLong{0, -10}, 270},
+		{LatLong{0, 179}, LatLong{0, -179}, 90}, // across the date line
+	}
+	for _, tt := range tests {
+		if got := Bearing(tt.p, tt.q); !near(got, tt.bearing, 1e-9) {
+			t.Errorf("Bearing(%v, %v) = %v, want %v", tt.p, tt.q, got, tt.bearing)
+		}
+	}
+
+	if m := Midpoint(LatLong{0, 179}, LatLong{0, -179}); !near(m.Lat, 0, 1e-9) || !near(math.Abs(m.Long), 180, 1e-9) {
+		t.Errorf("Midpoint across the date line = %v, want {0 ±180}", m)
+	}
+
+	// going the distance at the initial bearing arrives at the other point, and the
+	// midpoint is half way
+	d, b := Haversine(bellLabs, google), Bearing(bellLabs, google)
+	if got := Destination(bellLabs, b, d); !near(got.Lat, google.Lat, 1e-6) || !near(got.Long, google.Long, 1e-6) {
+		t.Errorf("Destination(Bell Labs, %.2f°, %.0f m) = %v, want %v", b, d, got, google)
+	}
+	m := Midpoint(bellLabs, google)
+	if d1, d2 := Haversine(bellLabs, m), Haversine(m, google); !near(d1, d/2, 1e-3) || !near(d2, d/2, 1e-3) {
+		t.Errorf("midpoint is %.3f and %.3f m away, want %.3f", d1, d2, d/2)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		p    LatLong
+		want error
+	}{
+		{bellLabs, nil},
+		{LatLong{90, 180}, nil},
+		{LatLong{-90, -180}, nil},
+		{LatLong{90.1, 0}, ErrInvalidLatitude},
+		{LatLong{math.NaN(), 0}, ErrInvalidLatitude},
+		{LatLong{0, -181}, ErrInvalidLongitude},
+		{LatLong{0, math.Inf(1)}, ErrInvalidLongitude},
+	}
+	for _, tt := range tests {
+		if err := tt.p.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("%v.Validate() = %v, want %v", tt.p, err, tt.want)
+		}
+	}
+}
+
+func TestParseDMS(t *testing.T) {
+	const bellLat = 40 + 41/60.0 + 3/3600.0
+	tests := []struct {
+		s    string
+		axis Axis
+		want float64
+	}{
+		{`40°41'03"N`, Latitude, bellLat},
+		{`40°41'03"S`, Latitude, -bellLat},
+		{`N 40° 41′ 03″`, Latitude, bellLat},
+		{`40d41m03s N`, Latitude, bellLat},
+		{`40°41'03''`, Latitude, bellLat},
+		{`40 41 03`, Latitude, bellLat},
+		{`40° 30' N`, Latitude, 40.5},
+		{`40°30.5'`, Latitude, 40 + 30.5/60},
+		{`-74.39967`, Longitude, -74.39967},
+		{`74°23'59"W`, Longitude, -(74 + 23/60.0 + 59/3600.0)},
+		{`180E`, Longitude, 180},
+	}
+	for _, tt := range tests {
+		got, err := ParseDMS(tt.s, tt.axis)
+		if err != nil || !near(got, tt.want, 1e-12) {
+			t.Errorf("ParseDMS(%q) = %v, %v, want %v", tt.s, got, err, tt.want)
+		}
+	}
+
+	for _, s := range []string{"", "N", "40°41'03\"E", "-40N", "40°61'", "40'41°", "40 41 03 04", "4x", "91N"} {
+		if got, err := ParseDMS(s, Latitude); err == nil {
+			t.Errorf("ParseDMS(%q) = %v, want an error", s, got)
+		}
+	}
+}
+
+func TestFormatDMS(t *testing.T) {
+	if got, want := bellLabs.DMS(), `40°41'04"N 74°23'59"W`; got != want {
+		t.Errorf("DMS() = %s, want %s", got, want)
+	}
+	// seconds rounding up carries into minutes and degrees
+	if got, want := FormatDMS(-10.99999, Longitude), `11°00'00"W`; got != want {
+		t.Errorf("FormatDMS = %s, want %s", got, want)
+	}
+	if got, want := FormatDMS(-0.00001, Latitude), `0°00'00"N`; got != want {
+		t.Errorf("FormatDMS = %s, want %s", got, want)
+	}
+	p, err := ParseLatLong(google.DMS())
+	if err != nil || !near(p.Lat, google.Lat, 1.0/3600) || !near(p.Long, google.Long, 1.0/3600) {
+		t.Errorf("ParseLatLong(%q) = %v, %v, want %v", google.DMS(), p, err, google)
+	}
+}
+
+func TestParseLatLong(t *testing.T) {
+	for _, s := range []string{
+		`40°41'03"N 74°23'59"W`,
+		`40°41'03"N, 74°23'59"W`,
+		`N40°41'03" W74°23'59"`,
+		`40.684167 -74.399722`,
+		`40.684167,-74.399722`,
+	} {
+		p, err := ParseLatLong(s)
+		if err != nil || !near(p.Lat, 40.684167, 1e-6) || !near(p.Long, -74.399722, 1e-6) {
+			t.Errorf("ParseLatLong(%q) = %v, %v", s, p, err)
+		}
+	}
+	if _, err := ParseLatLong("40.7"); !errors.Is(err, ErrInvalidDMS) {
+		t.Errorf("ParseLatLong with one number returned %v, want %v", err, ErrInvalidDMS)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	// the maps lesson's map literal
+	r := Registry{"Bell Labs": bellLabs, "Google": google}
+	if err := r.Add("Times Square", LatLong{40.758, -73.9855}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add("Nowhere", LatLong{100, 0}); !errors.Is(err, ErrInvalidLatitude) {
+		t.Errorf("Add with latitude 100 returned %v", err)
+	}
+
+	n, ok := r.Nearest(LatLong{37.77, -122.42}) // San Francisco
+	if !ok || n.Name != "Google" {
+		t.Errorf("Nearest(San Francisco) = %v, want Google", n)
+	}
+	n, err := r.NearestTo("Bell Labs")
+	if err != nil || n.Name != "Times Square" || !near(n.Distance, 35852, 1) {
+		t.Errorf("NearestTo(Bell Labs) = %+v, %v, want Times Square 35852 m away", n, err)
+	}
+	if _, err := r.NearestTo("Mars"); !errors.Is(err, ErrUnknownLocation) {
+		t.Errorf("NearestTo(Mars) returned %v, want %v", err, ErrUnknownLocation)
+	}
+
+	within := r.Within(bellLabs, 100e3)
+	if len(within) != 2 || within[0].Name != "Bell Labs" || within[1].Name != "Times Square" {
+		t.Errorf("Within(Bell Labs, 100 km) = %+v, want Bell Labs and Times Square", within)
+	}
+	if _, ok := (Registry{}).Nearest(bellLabs); ok {
+		t.Error("empty registry found a nearest location")
+	}
+}
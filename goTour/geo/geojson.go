@@ -0,0 +1,100 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GeoJSON (RFC 7946) as read: features are decoded one at a time so a bad one is a
+// RecordError instead of failing the whole file
+type featureCollection struct {
+	Type     string            `json:"type"`
+	Features []json.RawMessage `json:"features"`
+}
+
+type feature struct {
+	Type       string                 `json:"type"`
+	Geometry   *geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// ReadGeoJSON reads a FeatureCollection of Point features named by their "name"
+// property. Features that aren't named points are reported as a RecordErrors with their
+// numbers, counting from 1; the Registry holds the others.
+func ReadGeoJSON(in io.Reader) (Registry, error) {
+	var fc featureCollection
+	if err := json.NewDecoder(in).Decode(&fc); err != nil {
+		return nil, fmt.Errorf("geo: reading GeoJSON: %w", err)
+	}
+	if fc.Type != "FeatureCollection" {
+		return nil, fmt.Errorf("%w: GeoJSON type %q, want FeatureCollection", ErrInvalidRecord, fc.Type)
+	}
+	rs := records{r: make(Registry)}
+	for i, raw := range fc.Features {
+		name, p, err := readFeature(raw)
+		rs.add(0, i+1, name, p, err)
+	}
+	return rs.result()
+}
+
+// name and location of a Point feature
+func readFeature(raw json.RawMessage) (string, LatLong, error) {
+	var f feature
+	if err := json.Unmarshal(raw, &f); err != nil {
+		return "", LatLong{}, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	if f.Type != "Feature" {
+		return "", LatLong{}, fmt.Errorf("%w: type %q, want Feature", ErrInvalidRecord, f.Type)
+	}
+	if f.Geometry == nil || f.Geometry.Type != "Point" {
+		return "", LatLong{}, fmt.Errorf("%w: geometry is not a Point", ErrInvalidRecord)
+	}
+	name, ok := f.Properties["name"].(string)
+	if !ok {
+		return "", LatLong{}, fmt.Errorf("%w: no name property", ErrInvalidRecord)
+	}
+	// GeoJSON puts the longitude first, and may add an altitude
+	var coords []float64
+	if err := json.Unmarshal(f.Geometry.Coordinates, &coords); err != nil || len(coords) < 2 || len(coords) > 3 {
+		return name, LatLong{}, fmt.Errorf("%w: coordinates %s, want [long, lat]", ErrInvalidRecord, f.Geometry.Coordinates)
+	}
+	return name, LatLong{Lat: coords[1], Long: coords[0]}, nil
+}
+
+// GeoJSON as written
+type pointFeature struct {
+	Type       string            `json:"type"`
+	Geometry   point             `json:"geometry"`
+	Properties map[string]string `json:"properties"`
+}
+
+type point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"` // long, lat
+}
+
+// WriteGeoJSON writes r as an indented FeatureCollection of Point features with a name
+// property, in order of name
+func (r Registry) WriteGeoJSON(w io.Writer) error {
+	features := make([]pointFeature, 0, len(r))
+	for _, name := range r.names() {
+		p := r[name]
+		features = append(features, pointFeature{
+			Type:       "Feature",
+			Geometry:   point{Type: "Point", Coordinates: [2]float64{p.Long, p.Lat}},
+			Properties: map[string]string{"name": name},
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Type     string         `json:"type"`
+		Features []pointFeature `json:"features"`
+	}{"FeatureCollection", features})
+}
@@ -0,0 +1,289 @@
+package geo
+
+import (
+	"math"
+	"sort"
+)
+
+// cell size in degrees used when NewIndex is given 0: about 11 km north to south
+const DefaultCellSize = 0.1
+
+// Index is a spatial index over named locations: a grid of cells a few kilometres wide,
+// so radius, nearest and bounding box queries only look at the cells they could match
+// instead of every location. Set and Delete change it like m[name] = p and
+// delete(m, name) change the maps lesson's map.
+type Index struct {
+	size       float64 // cell size in degrees
+	rows, cols int
+	points     map[string]LatLong
+	cells      map[cell][]entry
+}
+
+// grid cell: rows go north from the south pole, columns east from the antimeridian
+type cell struct {
+	row, col int
+}
+
+// location stored in a cell
+type entry struct {
+	name string
+	p    LatLong
+}
+
+// NewIndex returns an empty index with cells of about cellSize degrees (DefaultCellSize
+// if 0); the size is rounded so the cells fit around the Earth exactly
+func NewIndex(cellSize float64) *Index {
+	if !(cellSize > 0) {
+		cellSize = DefaultCellSize
+	}
+	cols := int(math.Ceil(360 / math.Min(cellSize, 360)))
+	size := 360 / float64(cols)
+	return &Index{
+		size:   size,
+		rows:   int(math.Ceil(180 / size)),
+		cols:   cols,
+		points: make(map[string]LatLong),
+		cells:  make(map[cell][]entry),
+	}
+}
+
+// BuildIndex returns an index of the locations in m, such as the maps lesson's
+// map[string]Vertex2
+func BuildIndex(m Registry, cellSize float64) (*Index, error) {
+	idx := NewIndex(cellSize)
+	for name, p := range m {
+		if err := idx.Set(name, p); err != nil {
+			return nil, err
+		}
+	}
+	return idx, nil
+}
+
+// Len returns the number of locations
+func (idx *Index) Len() int {
+	return len(idx.points)
+}
+
+// Get returns the location called name, like v, ok := m[name]
+func (idx *Index) Get(name string) (LatLong, bool) {
+	p, ok := idx.points[name]
+	return p, ok
+}
+
+// Set stores p under name, moving it if name is already there, like m[name] = p
+func (idx *Index) Set(name string, p LatLong) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	c := idx.cellOf(p)
+	if old, ok := idx.points[name]; ok {
+		if oc := idx.cellOf(old); oc == c {
+			entries := idx.cells[c]
+			entries[indexOf(entries, name)].p = p
+			idx.points[name] = p
+			return nil
+		}
+		idx.remove(name, old)
+	}
+	idx.points[name] = p
+	idx.cells[c] = append(idx.cells[c], entry{name, p})
+	return nil
+}
+
+// Delete removes the location called name, if there is one, like delete(m, name)
+func (idx *Index) Delete(name string) {
+	if p, ok := idx.points[name]; ok {
+		idx.remove(name, p)
+		delete(idx.points, name)
+	}
+}
+
+// take name out of the cell of p
+func (idx *Index) remove(name string, p LatLong) {
+	c := idx.cellOf(p)
+	entries := idx.cells[c]
+	i := indexOf(entries, name)
+	entries[i] = entries[len(entries)-1]
+	entries = entries[:len(entries)-1]
+	if len(entries) == 0 {
+		delete(idx.cells, c)
+		return
+	}
+	idx.cells[c] = entries
+}
+
+// position of name in entries
+func indexOf(entries []entry, name string) int {
+	for i := range entries {
+		if entries[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// cell holding p
+func (idx *Index) cellOf(p LatLong) cell {
+	return cell{idx.row(p.Lat), idx.col(p.Long)}
+}
+
+// row of latitude lat, counting from the south pole
+func (idx *Index) row(lat float64) int {
+	r := int(math.Floor((lat + 90) / idx.size))
+	if r >= idx.rows {
+		r = idx.rows - 1
+	}
+	if r < 0 {
+		r = 0
+	}
+	return r
+}
+
+// column of longitude long, wrapping around the antimeridian
+func (idx *Index) col(long float64) int {
+	c := int(math.Floor((long + 180) / idx.size))
+	return ((c % idx.cols) + idx.cols) % idx.cols
+}
+
+// Within returns the locations at most radius metres from p, nearest first (the same
+// as Registry.Within, without measuring the distance to every location)
+func (idx *Index) Within(p LatLong, radius float64) []Neighbor {
+	found := idx.within(p, radius)
+	sortNeighbors(found)
+	return found
+}
+
+// locations at most radius metres from p, in no particular order
+func (idx *Index) within(p LatLong, radius float64) []Neighbor {
+	var found []Neighbor
+	idx.visit(idx.circleCells(p, radius), func(e entry) {
+		if d := Haversine(p, e.p); d <= radius {
+			found = append(found, Neighbor{e.name, e.p, d})
+		}
+	})
+	return found
+}
+
+// Nearest returns the k locations nearest to p, nearest first (all of them if there are
+// fewer than k)
+func (idx *Index) Nearest(p LatLong, k int) []Neighbor {
+	if k <= 0 || len(idx.points) == 0 {
+		return nil
+	}
+	// search ever larger circles until one holds k locations: none outside it can be
+	// nearer than those inside
+	halfway := math.Pi * EarthRadius
+	for radius := idx.size * math.Pi / 180 * EarthRadius; ; radius *= 2 {
+		if radius >= halfway {
+			radius = halfway
+		}
+		found := idx.within(p, radius)
+		if len(found) >= k || radius == halfway {
+			sortNeighbors(found)
+			if len(found) > k {
+				found = found[:k]
+			}
+			return found
+		}
+	}
+}
+
+// corners of an area: Min is the south west corner and Max the north east one. If
+// Min.Long > Max.Long the box crosses the antimeridian.
+type Box struct {
+	Min, Max LatLong
+}
+
+// Contains reports whether p is inside or on the edge of b
+func (b Box) Contains(p LatLong) bool {
+	if p.Lat < b.Min.Lat || p.Lat > b.Max.Lat {
+		return false
+	}
+	if b.Min.Long <= b.Max.Long {
+		return b.Min.Long <= p.Long && p.Long <= b.Max.Long
+	}
+	return p.Long >= b.Min.Long || p.Long <= b.Max.Long
+}
+
+// InBox returns the locations inside b as a Registry
+func (idx *Index) InBox(b Box) Registry {
+	found := make(Registry)
+	span := b.Max.Long - b.Min.Long
+	if span < 0 {
+		span += 360
+	}
+	cells := idx.cellRange(b.Min.Lat, b.Max.Lat, b.Min.Long, span)
+	idx.visit(cells, func(e entry) {
+		if b.Contains(e.p) {
+			found[e.name] = e.p
+		}
+	})
+	return found
+}
+
+// range of cells: rows from row0 to row1, cols columns east from col0 (wrapping)
+type cellRange struct {
+	row0, row1, col0, cols int
+}
+
+// cells covering latitudes lat0 to lat1 and span degrees of longitude east of long0
+func (idx *Index) cellRange(lat0, lat1, long0, span float64) cellRange {
+	r := cellRange{row0: idx.row(lat0), row1: idx.row(lat1), col0: idx.col(long0), cols: idx.cols}
+	if span < 360-idx.size {
+		// the columns of both ends, and all between (wider spans may end in the first column)
+		r.cols = ((idx.col(long0+span)-r.col0)%idx.cols+idx.cols)%idx.cols + 1
+	}
+	return r
+}
+
+// cells that may hold points within radius metres of p
+func (idx *Index) circleCells(p LatLong, radius float64) cellRange {
+	const margin = 1e-9           // degrees, for rounding errors at the edge of the circle
+	angle := radius / EarthRadius // in radians
+	dLat := angle*180/math.Pi + margin
+	lat0, lat1 := p.Lat-dLat, p.Lat+dLat
+	if lat0 <= -90 || lat1 >= 90 || angle >= math.Pi/2 {
+		// the circle holds a pole: any longitude
+		return idx.cellRange(math.Max(lat0, -90), math.Min(lat1, 90), -180, 360)
+	}
+	// widest longitude difference of points in the circle
+	s := math.Sin(angle) / math.Cos(p.Lat*math.Pi/180)
+	if s >= 1 {
+		return idx.cellRange(lat0, lat1, -180, 360)
+	}
+	dLong := math.Asin(s)*180/math.Pi + margin
+	return idx.cellRange(lat0, lat1, p.Long-dLong, 2*dLong)
+}
+
+// call f with every entry in the cells of r; goes through the non-empty cells instead
+// when there are fewer of them
+func (idx *Index) visit(r cellRange, f func(entry)) {
+	if (r.row1-r.row0+1)*r.cols > len(idx.cells) {
+		for c, entries := range idx.cells {
+			if c.row < r.row0 || c.row > r.row1 || ((c.col-r.col0)%idx.cols+idx.cols)%idx.cols >= r.cols {
+				continue
+			}
+			for _, e := range entries {
+				f(e)
+			}
+		}
+		return
+	}
+	for row := r.row0; row <= r.row1; row++ {
+		for i := 0; i < r.cols; i++ {
+			for _, e := range idx.cells[cell{row, (r.col0 + i) % idx.cols}] {
+				f(e)
+			}
+		}
+	}
+}
+
+// order neighbors nearest first, then by name
+func sortNeighbors(n []Neighbor) {
+	sort.Slice(n, func(i, j int) bool {
+		if n[i].Distance != n[j].Distance {
+			return n[i].Distance < n[j].Distance
+		}
+		return n[i].Name < n[j].Name
+	})
+}
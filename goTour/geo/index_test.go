@@ -0,0 +1,233 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// n locations spread evenly over the sphere
+func randomRegistry(rnd *rand.Rand, n int) Registry {
+	r := make(Registry, n)
+	for i := 0; i < n; i++ {
+		r[fmt.Sprint("p", i)] = randomPoint(rnd)
+	}
+	return r
+}
+
+// location picked evenly over the sphere
+func randomPoint(rnd *rand.Rand) LatLong {
+	return LatLong{math.Asin(2*rnd.Float64()-1) * 180 / math.Pi, rnd.Float64()*360 - 180}
+}
+
+func sameNeighbors(got, want []Neighbor) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i].Name != want[i].Name {
+			return false
+		}
+	}
+	return true
+}
+
+func TestIndexMatchesScan(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	r := randomRegistry(rnd, 10000)
+	// crowd the date line and the poles, where the grid wraps and narrows
+	for i := 0; i < 200; i++ {
+		r[fmt.Sprint("date line ", i)] = LatLong{rnd.Float64()*20 - 10, 180 - rnd.Float64()*0.5}
+		r[fmt.Sprint("date line west ", i)] = LatLong{rnd.Float64()*20 - 10, -180 + rnd.Float64()*0.5}
+		r[fmt.Sprint("north pole ", i)] = LatLong{90 - rnd.Float64(), rnd.Float64()*360 - 180}
+		r[fmt.Sprint("south pole ", i)] = LatLong{-90 + rnd.Float64(), rnd.Float64()*360 - 180}
+	}
+	r["pole"] = LatLong{90, 0}
+
+	centres := []LatLong{{0, 180}, {0, -179.9}, {89.9, 45}, {-89.95, -120}, {90, 0}, bellLabs}
+	for i := 0; i < 30; i++ {
+		centres = append(centres, randomPoint(rnd))
+	}
+	// every location by distance from each centre, to cut the answers from
+	scans := make([][]Neighbor, len(centres))
+	for i, p := range centres {
+		scans[i] = r.Within(p, math.Inf(1))
+	}
+
+	for _, size := range []float64{0, 1, 7} {
+		idx, err := BuildIndex(r, size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx.Len() != len(r) {
+			t.Errorf("cell size %v: Len() = %d, want %d", size, idx.Len(), len(r))
+		}
+		for i, p := range centres {
+			for _, radius := range []float64{0, 10e3, 150e3, 2000e3, 15000e3, 30000e3} {
+				want := scans[i]
+				for j, n := range want {
+					if n.Distance > radius {
+						want = want[:j]
+						break
+					}
+				}
+				if got := idx.Within(p, radius); !sameNeighbors(got, want) {
+					t.Errorf("cell size %v: Within(%v, %v) found %d locations, want %d", size, p, radius, len(got), len(want))
+				}
+			}
+			for _, k := range []int{1, 5, 40} {
+				if got, want := idx.Nearest(p, k), scans[i][:k]; !sameNeighbors(got, want) {
+					t.Errorf("cell size %v: Nearest(%v, %d) = %v, want %v", size, p, k, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestIndexInBox(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	r := randomRegistry(rnd, 5000)
+	idx, err := BuildIndex(r, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	boxes := []Box{
+		{LatLong{40, -75}, LatLong{45, -70}},
+		{LatLong{-10, 170}, LatLong{10, -170}}, // across the antimeridian
+		{LatLong{80, -180}, LatLong{90, 180}},
+		{LatLong{-90, -180}, LatLong{90, 180}},
+		{LatLong{0, 10}, LatLong{0.1, 10}},
+	}
+	for _, b := range boxes {
+		got := idx.InBox(b)
+		want := 0
+		for name, p := range r {
+			if b.Contains(p) {
+				want++
+				if _, ok := got[name]; !ok {
+					t.Errorf("InBox(%v) is missing %s %v", b, name, p)
+				}
+			}
+		}
+		if len(got) != want {
+			t.Errorf("InBox(%v) found %d locations, want %d", b, len(got), want)
+		}
+	}
+}
+
+func TestIndexSetDelete(t *testing.T) {
+	idx := NewIndex(0)
+	if err := idx.Set("Bell Labs", bellLabs); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Set("Google", google); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Set("Nowhere", LatLong{0, 200}); !errors.Is(err, ErrInvalidLongitude) {
+		t.Errorf("Set with longitude 200 returned %v", err)
+	}
+
+	// moving a location, within its cell and to another one, like m[name] = p
+	moved := LatLong{bellLabs.Lat + 0.001, bellLabs.Long}
+	idx.Set("Bell Labs", moved)
+	if p, ok := idx.Get("Bell Labs"); !ok || p != moved {
+		t.Errorf("Get(Bell Labs) = %v, %v after moving it to %v", p, ok, moved)
+	}
+	idx.Set("Google", bellLabs)
+	if n := idx.Within(bellLabs, 1e3); len(n) != 2 || n[0].Name != "Google" {
+		t.Errorf("Within(Bell Labs, 1 km) = %v after moving Google there", n)
+	}
+	if n := idx.Within(google, 1e3); len(n) != 0 {
+		t.Errorf("Google's old location still holds %v", n)
+	}
+
+	idx.Delete("Google")
+	idx.Delete("Mars")
+	if _, ok := idx.Get("Google"); ok || idx.Len() != 1 {
+		t.Errorf("after Delete(Google): Len() = %d, Get found it %v", idx.Len(), ok)
+	}
+	if n := idx.Nearest(google, 3); len(n) != 1 || n[0].Name != "Bell Labs" {
+		t.Errorf("Nearest(Google, 3) = %v, want Bell Labs only", n)
+	}
+	idx.Delete("Bell Labs")
+	if n := idx.Nearest(google, 1); n != nil || len(idx.cells) != 0 {
+		t.Errorf("empty index found %v and has %d cells", n, len(idx.cells))
+	}
+}
+
+// a million locations, built once for all the benchmarks
+var (
+	benchOnce     sync.Once
+	benchRegistry Registry
+	benchIndex    *Index
+	benchCentres  []LatLong
+)
+
+func benchData(b *testing.B) (Registry, *Index, []LatLong) {
+	benchOnce.Do(func() {
+		rnd := rand.New(rand.NewSource(3))
+		benchRegistry = randomRegistry(rnd, 1000000)
+		var err error
+		if benchIndex, err = BuildIndex(benchRegistry, 0); err != nil {
+			panic(err)
+		}
+		for i := 0; i < 1000; i++ {
+			benchCentres = append(benchCentres, randomPoint(rnd))
+		}
+	})
+	b.ResetTimer()
+	return benchRegistry, benchIndex, benchCentres
+}
+
+func BenchmarkIndexWithin(b *testing.B) {
+	_, idx, centres := benchData(b)
+	for i := 0; i < b.N; i++ {
+		idx.Within(centres[i%len(centres)], 50e3)
+	}
+}
+
+func BenchmarkScanWithin(b *testing.B) {
+	r, _, centres := benchData(b)
+	for i := 0; i < b.N; i++ {
+		r.Within(centres[i%len(centres)], 50e3)
+	}
+}
+
+func BenchmarkIndexNearest(b *testing.B) {
+	_, idx, centres := benchData(b)
+	for i := 0; i < b.N; i++ {
+		idx.Nearest(centres[i%len(centres)], 10)
+	}
+}
+
+func BenchmarkScanNearest(b *testing.B) {
+	r, _, centres := benchData(b)
+	for i := 0; i < b.N; i++ {
+		scanNearest(r, centres[i%len(centres)], 10)
+	}
+}
+
+// brute force k nearest: one pass over r keeping the k best so far in order
+func scanNearest(r Registry, p LatLong, k int) []Neighbor {
+	best := make([]Neighbor, 0, k+1)
+	for name, q := range r {
+		d := Haversine(p, q)
+		if len(best) == k && d >= best[k-1].Distance {
+			continue
+		}
+		i := len(best)
+		for i > 0 && best[i-1].Distance > d {
+			i--
+		}
+		best = append(best, Neighbor{})
+		copy(best[i+1:], best[i:])
+		best[i] = Neighbor{name, q, d}
+		if len(best) > k {
+			best = best[:k]
+		}
+	}
+	return best
+}
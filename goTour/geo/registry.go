@@ -0,0 +1,76 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownLocation is returned for a name that isn't in a Registry
+var ErrUnknownLocation = errors.New("geo: unknown location")
+
+// named locations: the maps lesson's map[string]Vertex2, so Registry(m) answers questions
+// about a map like that one
+type Registry map[string]LatLong
+
+// location found by a search, with its distance from where the search started
+type Neighbor struct {
+	Name string
+	LatLong
+	Distance float64 // haversine distance in metres
+}
+
+// Add stores p under name after checking it is a valid location
+func (r Registry) Add(name string, p LatLong) error {
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	r[name] = p
+	return nil
+}
+
+// Nearest returns the location closest to p (false if r is empty)
+func (r Registry) Nearest(p LatLong) (Neighbor, bool) {
+	return r.nearest(p, "", false)
+}
+
+// NearestTo returns the location closest to the one called name, other than itself
+func (r Registry) NearestTo(name string) (Neighbor, error) {
+	p, ok := r[name]
+	if !ok {
+		return Neighbor{}, fmt.Errorf("%w: %q", ErrUnknownLocation, name)
+	}
+	n, ok := r.nearest(p, name, true)
+	if !ok {
+		return Neighbor{}, fmt.Errorf("%w: no locations besides %q", ErrUnknownLocation, name)
+	}
+	return n, nil
+}
+
+// closest location to p, leaving out skip if skipping; ties go to the first name in
+// alphabetical order so the result doesn't depend on map order
+func (r Registry) nearest(p LatLong, skip string, skipping bool) (Neighbor, bool) {
+	var best Neighbor
+	found := false
+	for name, q := range r {
+		if skipping && name == skip {
+			continue
+		}
+		d := Haversine(p, q)
+		if !found || d < best.Distance || (d == best.Distance && name < best.Name) {
+			best, found = Neighbor{name, q, d}, true
+		}
+	}
+	return best, found
+}
+
+// Within returns the locations at most radius metres from p, nearest first
+func (r Registry) Within(p LatLong, radius float64) []Neighbor {
+	var found []Neighbor
+	for name, q := range r {
+		if d := Haversine(p, q); d <= radius {
+			found = append(found, Neighbor{name, q, d})
+		}
+	}
+	sortNeighbors(found)
+	return found
+}
@@ -0,0 +1,156 @@
+// Package geometry provides shapes built on the vector package's Vertex: polygons,
+// circles, rectangles and line segments sharing a Shape interface, so they can be mixed
+// in one slice the way the methods lesson mixes Abser values, plus convex hulls,
+// bounding boxes and segment intersection.
+//
+// Points on the boundary of a shape count as inside it.
+package geometry
+
+import (
+	"math"
+
+	"goTour/vector"
+)
+
+// point in the plane
+type Point = vector.Vertex[float64]
+
+// Pt returns the point (x, y)
+func Pt(x, y float64) Point {
+	return Point{X: x, Y: y}
+}
+
+// set of methods every shape has
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+	Centroid() Point   // centre of mass of the shape's area
+	Bounds() Rectangle // smallest axis aligned rectangle holding the shape
+	Contains(p Point) bool
+}
+
+// check the shapes implement Shape
+var (
+	_ Shape = Polygon{}
+	_ Shape = Circle{}
+	_ Shape = Rectangle{}
+	_ Shape = LineSegment{}
+)
+
+// circle with its centre and radius
+type Circle struct {
+	Center Point
+	Radius float64
+}
+
+// Area returns Pi*r^2
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Perimeter returns the circumference 2*Pi*r
+func (c Circle) Perimeter() float64 {
+	return 2 * math.Pi * c.Radius
+}
+
+// Centroid returns the centre
+func (c Circle) Centroid() Point {
+	return c.Center
+}
+
+// Bounds returns the square around the circle
+func (c Circle) Bounds() Rectangle {
+	r := Pt(c.Radius, c.Radius)
+	return Rectangle{c.Center.Sub(r), c.Center.Add(r)}
+}
+
+// Contains reports whether p is inside or on the circle
+func (c Circle) Contains(p Point) bool {
+	return c.Center.Distance(p) <= c.Radius
+}
+
+// axis aligned rectangle from its lower left corner Min to its upper right corner Max
+type Rectangle struct {
+	Min, Max Point
+}
+
+// Rect returns the rectangle with corners (x0, y0) and (x1, y1) in any order
+func Rect(x0, y0, x1, y1 float64) Rectangle {
+	return Rectangle{Pt(math.Min(x0, x1), math.Min(y0, y1)), Pt(math.Max(x0, x1), math.Max(y0, y1))}
+}
+
+// Dx returns the width
+func (r Rectangle) Dx() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Dy returns the height
+func (r Rectangle) Dy() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Area returns width * height
+func (r Rectangle) Area() float64 {
+	return r.Dx() * r.Dy()
+}
+
+// Perimeter returns 2 * (width + height)
+func (r Rectangle) Perimeter() float64 {
+	return 2 * (r.Dx() + r.Dy())
+}
+
+// Centroid returns the centre
+func (r Rectangle) Centroid() Point {
+	return r.Min.Lerp(r.Max, 0.5)
+}
+
+// Bounds returns r itself
+func (r Rectangle) Bounds() Rectangle {
+	return r
+}
+
+// Contains reports whether p is inside or on the edge of r
+func (r Rectangle) Contains(p Point) bool {
+	return r.Min.X <= p.X && p.X <= r.Max.X && r.Min.Y <= p.Y && p.Y <= r.Max.Y
+}
+
+// Intersects reports whether r and s overlap or touch
+func (r Rectangle) Intersects(s Rectangle) bool {
+	return r.Min.X <= s.Max.X && s.Min.X <= r.Max.X && r.Min.Y <= s.Max.Y && s.Min.Y <= r.Max.Y
+}
+
+// Union returns the smallest rectangle holding both r and s
+func (r Rectangle) Union(s Rectangle) Rectangle {
+	return Rectangle{r.Min.Min(s.Min), r.Max.Max(s.Max)}
+}
+
+// Polygon returns the corners counterclockwise, starting at Min
+func (r Rectangle) Polygon() Polygon {
+	return Polygon{r.Min, Pt(r.Max.X, r.Min.Y), r.Max, Pt(r.Min.X, r.Max.Y)}
+}
+
+// BoundingBox returns the smallest rectangle holding all the points (the zero
+// Rectangle if there are none)
+func BoundingBox(points ...Point) Rectangle {
+	if len(points) == 0 {
+		return Rectangle{}
+	}
+	r := Rectangle{points[0], points[0]}
+	for _, p := range points[1:] {
+		r.Min, r.Max = r.Min.Min(p), r.Max.Max(p)
+	}
+	return r
+}
+
+// BoundsOf returns the smallest rectangle holding all the shapes (the zero Rectangle
+// if there are none)
+func BoundsOf(shapes ...Shape) Rectangle {
+	if len(shapes) == 0 {
+		return Rectangle{}
+	}
+	r := shapes[0].Bounds()
+	for _, s := range shapes[1:] {
+		r = r.Union(s.Bounds())
+	}
+	return r
+}
@@ -0,0 +1,165 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+func near(got, want float64) bool {
+	return math.Abs(got-want) <= eps
+}
+
+func TestShapes(t *testing.T) {
+	tests := []struct {
+		name      string
+		s         Shape
+		area      float64
+		perimeter float64
+		centroid  Point
+		bounds    Rectangle
+	}{
+		{"unit circle", Circle{Pt(1, 2), 1}, math.Pi, 2 * math.Pi, Pt(1, 2), Rect(0, 1, 2, 3)},
+		{"rectangle", Rect(3, 4, 0, 0), 12, 14, Pt(1.5, 2), Rect(0, 0, 3, 4)},
+		{"right triangle", Polygon{Pt(0, 0), Pt(3, 0), Pt(0, 4)}, 6, 12, Pt(1, 4.0/3), Rect(0, 0, 3, 4)},
+		{"clockwise square", Polygon{Pt(0, 0), Pt(0, 2), Pt(2, 2), Pt(2, 0)}, 4, 8, Pt(1, 1), Rect(0, 0, 2, 2)},
+		// L shape: a 2x2 square with the top right 1x1 square cut out
+		{"L", Polygon{Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)}, 3, 8, Pt(5.0/6, 5.0/6), Rect(0, 0, 2, 2)},
+		{"flat polygon", Polygon{Pt(0, 0), Pt(1, 1), Pt(2, 2)}, 0, 4 * math.Sqrt2, Pt(1, 1), Rect(0, 0, 2, 2)},
+		{"segment", Seg(0, 0, 3, -4), 0, 10, Pt(1.5, -2), Rect(0, -4, 3, 0)},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Area(); !near(got, tt.area) {
+			t.Errorf("%s: Area() = %v, want %v", tt.name, got, tt.area)
+		}
+		if got := tt.s.Perimeter(); !near(got, tt.perimeter) {
+			t.Errorf("%s: Perimeter() = %v, want %v", tt.name, got, tt.perimeter)
+		}
+		if got := tt.s.Centroid(); !got.ApproxEqual(tt.centroid, eps) {
+			t.Errorf("%s: Centroid() = %v, want %v", tt.name, got, tt.centroid)
+		}
+		if got := tt.s.Bounds(); got != tt.bounds {
+			t.Errorf("%s: Bounds() = %v, want %v", tt.name, got, tt.bounds)
+		}
+	}
+
+	if a := (Polygon{Pt(0, 0), Pt(0, 2), Pt(2, 2), Pt(2, 0)}).SignedArea(); a != -4 {
+		t.Errorf("SignedArea() of a clockwise square = %v, want -4", a)
+	}
+	if a := Rect(0, 0, 2, 3).Polygon().SignedArea(); a != 6 {
+		t.Errorf("Rectangle.Polygon() has signed area %v, want 6 (counterclockwise)", a)
+	}
+	if c := (Polygon{}).Centroid(); c != (Point{}) {
+		t.Errorf("Centroid() of an empty polygon = %v", c)
+	}
+}
+
+func TestContains(t *testing.T) {
+	l := Polygon{Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)}
+	tests := []struct {
+		name string
+		s    Shape
+		p    Point
+		want bool
+	}{
+		{"L inside", l, Pt(0.5, 1.5), true},
+		{"L cut out corner", l, Pt(1.5, 1.5), false},
+		{"L corner", l, Pt(2, 0), true},
+		{"L reflex corner", l, Pt(1, 1), true},
+		{"L edge", l, Pt(1.5, 1), true},
+		{"L right of it on a corner's line", l, Pt(-1, 1), false},
+		{"L outside", l, Pt(3, 0.5), false},
+		{"circle edge", Circle{Pt(0, 0), 5}, Pt(3, 4), true},
+		{"circle outside", Circle{Pt(0, 0), 5}, Pt(3.1, 4), false},
+		{"rectangle edge", Rect(0, 0, 1, 1), Pt(1, 0.5), true},
+		{"rectangle outside", Rect(0, 0, 1, 1), Pt(1.01, 0.5), false},
+		{"segment middle", Seg(0, 0, 2, 2), Pt(1, 1), true},
+		{"segment end", Seg(0, 0, 2, 2), Pt(2, 2), true},
+		{"segment beyond the end", Seg(0, 0, 2, 2), Pt(3, 3), false},
+		{"off the segment", Seg(0, 0, 2, 2), Pt(1, 1.1), false},
+	}
+	for _, tt := range tests {
+		if got := tt.s.Contains(tt.p); got != tt.want {
+			t.Errorf("%s: Contains(%v) = %v, want %v", tt.name, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestRectangles(t *testing.T) {
+	a, b, c := Rect(0, 0, 2, 2), Rect(2, 1, 3, 3), Rect(2.5, -1, 4, 0.5)
+	if !a.Intersects(b) || !b.Intersects(a) {
+		t.Errorf("%v and %v touch but don't intersect", a, b)
+	}
+	if a.Intersects(c) {
+		t.Errorf("%v and %v intersect", a, c)
+	}
+	if got := a.Union(c); got != Rect(0, -1, 4, 2) {
+		t.Errorf("%v.Union(%v) = %v", a, c, got)
+	}
+	if got := BoundingBox(Pt(1, 5), Pt(-2, 3), Pt(0, 7)); got != Rect(-2, 3, 1, 7) {
+		t.Errorf("BoundingBox = %v, want %v", got, Rect(-2, 3, 1, 7))
+	}
+	if got := BoundsOf(Circle{Pt(0, 0), 1}, Seg(3, 3, 4, -2)); got != Rect(-1, -2, 4, 3) {
+		t.Errorf("BoundsOf = %v, want %v", got, Rect(-1, -2, 4, 3))
+	}
+	if BoundingBox() != (Rectangle{}) || BoundsOf() != (Rectangle{}) {
+		t.Error("bounds of nothing aren't the zero Rectangle")
+	}
+}
+
+func TestIsConvex(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Polygon
+		want bool
+	}{
+		{"square", Rect(0, 0, 1, 1).Polygon(), true},
+		{"clockwise triangle", Polygon{Pt(0, 0), Pt(0, 1), Pt(1, 0)}, true},
+		{"collinear corner", Polygon{Pt(0, 0), Pt(1, 0), Pt(2, 0), Pt(2, 2)}, true},
+		{"L", Polygon{Pt(0, 0), Pt(2, 0), Pt(2, 1), Pt(1, 1), Pt(1, 2), Pt(0, 2)}, false},
+		{"bow tie", Polygon{Pt(0, 0), Pt(1, 1), Pt(1, 0), Pt(0, 1)}, false},
+		{"line", Polygon{Pt(0, 0), Pt(1, 1), Pt(2, 2)}, false},
+		{"segment", Polygon{Pt(0, 0), Pt(1, 1)}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.IsConvex(); got != tt.want {
+			t.Errorf("%s: IsConvex() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestIntersection(t *testing.T) {
+	tests := []struct {
+		name string
+		s, t LineSegment
+		want Point
+		ok   bool
+	}{
+		{"crossing", Seg(0, 0, 2, 2), Seg(0, 2, 2, 0), Pt(1, 1), true},
+		{"T", Seg(0, 0, 4, 0), Seg(1, 0, 1, 3), Pt(1, 0), true},
+		{"shared end", Seg(0, 0, 1, 1), Seg(1, 1, 2, 0), Pt(1, 1), true},
+		{"apart", Seg(0, 0, 1, 1), Seg(2, 0, 3, -1), Point{}, false},
+		{"lines cross past the ends", Seg(0, 0, 1, 0), Seg(2, -1, 2, 1), Point{}, false},
+		{"parallel", Seg(0, 0, 2, 0), Seg(0, 1, 2, 1), Point{}, false},
+		{"collinear overlap", Seg(0, 0, 4, 0), Seg(6, 0, 2, 0), Pt(2, 0), true},
+		{"collinear overlap from s.A", Seg(1, 0, 4, 0), Seg(0, 0, 2, 0), Pt(1, 0), true},
+		{"collinear inside s", Seg(0, 0, 10, 0), Seg(7, 0, 3, 0), Pt(3, 0), true},
+		{"collinear apart", Seg(0, 0, 1, 0), Seg(2, 0, 3, 0), Point{}, false},
+		{"point on segment", Seg(0, 0, 2, 2), Seg(1, 1, 1, 1), Pt(1, 1), true},
+		{"segment through point", Seg(1, 1, 1, 1), Seg(0, 0, 2, 2), Pt(1, 1), true},
+		{"point off segment", Seg(0, 0, 2, 2), Seg(1, 0, 1, 0), Point{}, false},
+	}
+	for _, tt := range tests {
+		p, ok := tt.s.Intersection(tt.t)
+		if ok != tt.ok || ok && !p.ApproxEqual(tt.want, eps) {
+			t.Errorf("%s: %v.Intersection(%v) = %v, %v, want %v, %v", tt.name, tt.s, tt.t, p, ok, tt.want, tt.ok)
+		}
+		if got := tt.s.Intersects(tt.t); got != tt.ok {
+			t.Errorf("%s: Intersects() = %v, want %v", tt.name, got, tt.ok)
+		}
+		if got := tt.t.Intersects(tt.s); got != tt.ok {
+			t.Errorf("%s: Intersects() with the segments swapped = %v, want %v", tt.name, got, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package geometry
+
+import "sort"
+
+// polygon given by its corners in order (clockwise or counterclockwise), with an edge
+// from the last corner back to the first
+type Polygon []Point
+
+// SignedArea returns the area with the shoelace formula: positive if the corners are
+// counterclockwise, negative if clockwise
+func (p Polygon) SignedArea() float64 {
+	var sum float64
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		sum += a.Cross(b)
+	}
+	return sum / 2
+}
+
+// Area returns the area enclosed by p (for a simple polygon, one whose edges don't cross)
+func (p Polygon) Area() float64 {
+	a := p.SignedArea()
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Perimeter returns the total length of the edges
+func (p Polygon) Perimeter() float64 {
+	var sum float64
+	for _, e := range p.Edges() {
+		sum += e.Length()
+	}
+	return sum
+}
+
+// Centroid returns the centre of mass of the polygon's area, or the average of its
+// corners if it has no area (fewer than 3 corners, or all on one line)
+func (p Polygon) Centroid() Point {
+	if len(p) == 0 {
+		return Point{}
+	}
+	var cx, cy, area float64
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		cross := a.Cross(b)
+		area += cross
+		cx += (a.X + b.X) * cross
+		cy += (a.Y + b.Y) * cross
+	}
+	if area == 0 {
+		var sum Point
+		for _, c := range p {
+			sum = sum.Add(c)
+		}
+		return sum.Mul(1 / float64(len(p)))
+	}
+	// area is twice the signed area, so 6A = 3*area
+	return Pt(cx/(3*area), cy/(3*area))
+}
+
+// Bounds returns the bounding box of the corners
+func (p Polygon) Bounds() Rectangle {
+	return BoundingBox(p...)
+}
+
+// Edges returns the edges, from each corner to the next
+func (p Polygon) Edges() []LineSegment {
+	if len(p) < 2 {
+		return nil
+	}
+	edges := make([]LineSegment, len(p))
+	for i, a := range p {
+		edges[i] = LineSegment{a, p[(i+1)%len(p)]}
+	}
+	return edges
+}
+
+// Contains reports whether q is inside p or on one of its edges, by counting how many
+// edges a ray from q to the right crosses (even-odd rule)
+func (p Polygon) Contains(q Point) bool {
+	inside := false
+	for i, a := range p {
+		b := p[(i+1)%len(p)]
+		if (LineSegment{a, b}).Contains(q) {
+			return true
+		}
+		// edges that straddle the ray's line; the half-open test counts a corner on the
+		// ray once
+		if (a.Y > q.Y) != (b.Y > q.Y) {
+			x := a.X + (q.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if x > q.X {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// IsConvex reports whether every turn along p goes the same way (collinear corners allowed)
+func (p Polygon) IsConvex() bool {
+	if len(p) < 3 {
+		return false
+	}
+	sign := 0.0
+	for i, a := range p {
+		b, c := p[(i+1)%len(p)], p[(i+2)%len(p)]
+		turn := b.Sub(a).Cross(c.Sub(b))
+		if turn == 0 {
+			continue
+		}
+		if sign != 0 && (turn > 0) != (sign > 0) {
+			return false
+		}
+		sign = turn
+	}
+	return sign != 0
+}
+
+// ConvexHull returns the smallest convex polygon holding all the points, counterclockwise
+// starting from the lowest leftmost point, without collinear corners (Andrew's monotone
+// chain, O(n log n)). Fewer than 3 distinct points give those points.
+func ConvexHull(points []Point) Polygon {
+	ps := make([]Point, len(points))
+	copy(ps, points)
+	sort.Slice(ps, func(i, j int) bool {
+		if ps[i].X != ps[j].X {
+			return ps[i].X < ps[j].X
+		}
+		return ps[i].Y < ps[j].Y
+	})
+	// drop duplicates
+	unique := ps[:0]
+	for i, q := range ps {
+		if i == 0 || q != ps[i-1] {
+			unique = append(unique, q)
+		}
+	}
+	ps = unique
+	if len(ps) < 3 {
+		return Polygon(ps)
+	}
+
+	// lower hull left to right, then upper hull right to left; a corner is dropped while
+	// the last two corners and the new point don't make a counterclockwise turn
+	hull := make(Polygon, 0, 2*len(ps))
+	for _, q := range ps {
+		hull = addToHull(hull, q, 2)
+	}
+	lower := len(hull) + 1
+	for i := len(ps) - 2; i >= 0; i-- {
+		hull = addToHull(hull, ps[i], lower)
+	}
+	return hull[:len(hull)-1] // the last point is the first one again
+}
+
+// append q to the hull, first dropping corners that would make a clockwise or straight turn
+// while the hull has at least keep corners
+func addToHull(hull Polygon, q Point, keep int) Polygon {
+	for len(hull) >= keep {
+		a, b := hull[len(hull)-2], hull[len(hull)-1]
+		if b.Sub(a).Cross(q.Sub(b)) > 0 {
+			break
+		}
+		hull = hull[:len(hull)-1]
+	}
+	return append(hull, q)
+}
@@ -0,0 +1,76 @@
+package geometry
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestConvexHull(t *testing.T) {
+	tests := []struct {
+		name   string
+		points []Point
+		want   Polygon
+	}{
+		{"none", nil, Polygon{}},
+		{"one", []Point{Pt(1, 1)}, Polygon{Pt(1, 1)}},
+		{"duplicates", []Point{Pt(1, 1), Pt(0, 0), Pt(1, 1), Pt(0, 0)}, Polygon{Pt(0, 0), Pt(1, 1)}},
+		{"collinear", []Point{Pt(2, 2), Pt(0, 0), Pt(1, 1), Pt(3, 3)}, Polygon{Pt(0, 0), Pt(3, 3)}},
+		{"triangle", []Point{Pt(0, 4), Pt(3, 0), Pt(0, 0)}, Polygon{Pt(0, 0), Pt(3, 0), Pt(0, 4)}},
+		{
+			"square with inside and edge points",
+			[]Point{Pt(1, 1), Pt(2, 2), Pt(0, 2), Pt(1, 0), Pt(2, 0), Pt(0, 0), Pt(0, 1), Pt(1, 2)},
+			Polygon{Pt(0, 0), Pt(2, 0), Pt(2, 2), Pt(0, 2)},
+		},
+		{
+			"lowest leftmost first",
+			[]Point{Pt(3, 1), Pt(0, 3), Pt(0, 1), Pt(1, 0), Pt(2, 4)},
+			Polygon{Pt(0, 1), Pt(1, 0), Pt(3, 1), Pt(2, 4), Pt(0, 3)},
+		},
+	}
+	for _, tt := range tests {
+		if got := ConvexHull(tt.points); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ConvexHull(%v) = %v, want %v", tt.name, tt.points, got, tt.want)
+		}
+	}
+
+	// the input isn't reordered
+	points := []Point{Pt(2, 2), Pt(0, 0), Pt(1, 1)}
+	ConvexHull(points)
+	if points[0] != Pt(2, 2) {
+		t.Errorf("ConvexHull sorted its argument: %v", points)
+	}
+}
+
+// hulls of random points are convex, counterclockwise, made of input points and hold
+// every input point
+func TestConvexHullRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 3; n < 200; n += 7 {
+		points := make([]Point, n)
+		seen := make(map[Point]bool)
+		for i := range points {
+			// a coarse grid, so there are duplicates and collinear points
+			points[i] = Pt(float64(rnd.Intn(20)), float64(rnd.Intn(20)))
+			seen[points[i]] = true
+		}
+		hull := ConvexHull(points)
+		if len(seen) >= 3 && !hull.IsConvex() || hull.SignedArea() < 0 {
+			t.Fatalf("hull of %v is %v: not convex and counterclockwise", points, hull)
+		}
+		for i, c := range hull {
+			if !seen[c] {
+				t.Fatalf("hull corner %v isn't one of the points", c)
+			}
+			// no collinear corners
+			if a, b := hull[(i+len(hull)-1)%len(hull)], hull[(i+1)%len(hull)]; len(hull) > 2 && orientation(a, c, b) == 0 {
+				t.Fatalf("hull %v has a straight corner at %v", hull, c)
+			}
+		}
+		for _, p := range points {
+			if !hull.Contains(p) {
+				t.Fatalf("hull %v doesn't hold %v", hull, p)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package geometry
+
+import "math"
+
+// straight line from A to B
+type LineSegment struct {
+	A, B Point
+}
+
+// Seg returns the segment from (x0, y0) to (x1, y1)
+func Seg(x0, y0, x1, y1 float64) LineSegment {
+	return LineSegment{Pt(x0, y0), Pt(x1, y1)}
+}
+
+// Length returns the distance from A to B
+func (s LineSegment) Length() float64 {
+	return s.A.Distance(s.B)
+}
+
+// Area returns 0: a segment has no area
+func (s LineSegment) Area() float64 {
+	return 0
+}
+
+// Perimeter returns twice the length, like the perimeter of the polygon {A, B}
+func (s LineSegment) Perimeter() float64 {
+	return 2 * s.Length()
+}
+
+// Centroid returns the midpoint
+func (s LineSegment) Centroid() Point {
+	return s.A.Lerp(s.B, 0.5)
+}
+
+// Bounds returns the rectangle with A and B as opposite corners
+func (s LineSegment) Bounds() Rectangle {
+	return BoundingBox(s.A, s.B)
+}
+
+// Contains reports whether p lies on the segment
+func (s LineSegment) Contains(p Point) bool {
+	return orientation(s.A, s.B, p) == 0 && s.Bounds().Contains(p)
+}
+
+// Intersects reports whether s and t share at least one point (touching or overlapping
+// segments count)
+func (s LineSegment) Intersects(t LineSegment) bool {
+	_, ok := s.Intersection(t)
+	return ok
+}
+
+// Intersection returns a point s and t have in common. Crossing segments give the
+// crossing point; collinear, overlapping segments give the overlap's end nearest s.A.
+func (s LineSegment) Intersection(t LineSegment) (Point, bool) {
+	d1, d2 := orientation(t.A, t.B, s.A), orientation(t.A, t.B, s.B)
+	d3, d4 := orientation(s.A, s.B, t.A), orientation(s.A, s.B, t.B)
+
+	if d1 == 0 && d2 == 0 { // collinear (or degenerate): look for an overlap
+		return s.overlap(t)
+	}
+	if d1*d2 > 0 || d3*d4 > 0 { // both ends of one segment on the same side of the other
+		return Point{}, false
+	}
+
+	// s.A + (s.B-s.A)*u for the u where the lines cross
+	r, q := s.B.Sub(s.A), t.B.Sub(t.A)
+	denom := r.Cross(q)
+	if denom == 0 { // one segment is a point on the other
+		if t.A == t.B {
+			return t.A, true
+		}
+		return s.A, true
+	}
+	u := t.A.Sub(s.A).Cross(q) / denom
+	return s.A.Add(r.Mul(u)), true
+}
+
+// common point of collinear segments, nearest s.A
+func (s LineSegment) overlap(t LineSegment) (Point, bool) {
+	if s.Contains(t.A) || s.Contains(t.B) || t.Contains(s.A) {
+		if t.Contains(s.A) {
+			return s.A, true
+		}
+		// the end of t on s nearest s.A
+		best, dist := Point{}, math.Inf(1)
+		for _, p := range []Point{t.A, t.B} {
+			if d := s.A.Distance(p); s.Contains(p) && d < dist {
+				best, dist = p, d
+			}
+		}
+		return best, true
+	}
+	return Point{}, false
+}
+
+// sign of the turn from a to b to c: 1 counterclockwise, -1 clockwise, 0 collinear
+func orientation(a, b, c Point) float64 {
+	cross := b.Sub(a).Cross(c.Sub(a))
+	switch {
+	case cross > 0:
+		return 1
+	case cross < 0:
+		return -1
+	}
+	return 0
+}
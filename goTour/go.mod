@@ -0,0 +1,3 @@
+module goTour
+
+go 1.23
@@ -0,0 +1,127 @@
+// Package lesson keeps a registry of the runnable Go tour lessons.
+//
+// Each tour topic (basics, flowcontrol, moretypes, methods, concurrency) registers
+// its lessons from an init function, so importing a topic package is enough to make
+// its lessons available to the gotour command.
+package lesson
+
+import (
+	"io"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"goTour/clock"
+)
+
+// a single runnable lesson, e.g. concurrency/syncMutex
+type Lesson struct {
+	Topic string        // name of the topic the lesson belongs to
+	Name  string        // lesson name (the original function name, e.g. "syncMutex")
+	Doc   string        // one line description
+	Run   func(env Env) // run the lesson, writing its output to env.Out
+}
+
+// what a lesson runs against: where its output goes, where its random numbers come
+// from and what time it is, so a run can be repeated exactly with the same seed and clock
+type Env struct {
+	Out   io.Writer
+	Rand  rand.Source
+	Clock clock.Clock
+}
+
+// ID returns the lesson's "<topic>/<name>" identifier
+func (l Lesson) ID() string {
+	return l.Topic + "/" + l.Name
+}
+
+// group of lessons from one page of the tour
+type Topic struct {
+	Order   int    // position in the tour (1 = packages, variables, and functions)
+	Name    string // short name used in lesson IDs
+	Doc     string // one line description
+	Lessons []Lesson
+}
+
+// registered topics
+var topics []*Topic
+
+// NewTopic registers a new topic and returns it so lessons can be added to it.
+// Panics if a topic with the same name has already been registered.
+func NewTopic(order int, name, doc string) *Topic {
+	if strings.Contains(name, "/") {
+		panic("lesson: topic name " + name + " contains a '/'")
+	}
+	if FindTopic(name) != nil {
+		panic("lesson: topic " + name + " registered twice")
+	}
+	t := &Topic{Order: order, Name: name, Doc: doc}
+	topics = append(topics, t)
+	return t
+}
+
+// Add registers a lesson that only writes output. Panics if the name is already taken.
+func (t *Topic) Add(name, doc string, run func(w io.Writer)) {
+	t.AddEnv(name, doc, func(env Env) { run(env.Out) })
+}
+
+// AddEnv registers a lesson that needs more of its environment than the output writer
+// (e.g. a random source). Panics if the name is already taken.
+func (t *Topic) AddEnv(name, doc string, run func(env Env)) {
+	if _, ok := t.Find(name); ok {
+		panic("lesson: " + t.Name + "/" + name + " registered twice")
+	}
+	t.Lessons = append(t.Lessons, Lesson{Topic: t.Name, Name: name, Doc: doc, Run: run})
+}
+
+// Find returns the lesson with the given name within the topic
+func (t *Topic) Find(name string) (Lesson, bool) {
+	for _, l := range t.Lessons {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return Lesson{}, false
+}
+
+// Topics returns all registered topics in tour order
+func Topics() []*Topic {
+	sorted := make([]*Topic, len(topics))
+	copy(sorted, topics)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+	return sorted
+}
+
+// FindTopic returns the registered topic with the given name (nil if not found)
+func FindTopic(name string) *Topic {
+	for _, t := range topics {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// Lessons returns every registered lesson in tour order
+func Lessons() []Lesson {
+	var lessons []Lesson
+	for _, t := range Topics() {
+		lessons = append(lessons, t.Lessons...)
+	}
+	return lessons
+}
+
+// Find returns the lesson with the given "<topic>/<name>" ID
+func Find(id string) (Lesson, bool) {
+	topic, name, ok := strings.Cut(id, "/")
+	if !ok {
+		return Lesson{}, false
+	}
+	t := FindTopic(topic)
+	if t == nil {
+		return Lesson{}, false
+	}
+	return t.Find(name)
+}
@@ -0,0 +1,132 @@
+// Package lessontest compares the output of registered lessons with golden files.
+//
+// The golden file for lesson <topic>/<name> is testdata/<name>.golden in the topic's
+// package directory. Run the tests with -update to rewrite the golden files after an
+// intentional change:
+//
+//	go test ./flowcontrol -update
+package lessontest
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
+)
+
+var update = flag.Bool("update", false, "rewrite the lesson golden files with the current output")
+
+// Golden runs every lesson of the topic and compares its output with testdata/<name>.golden.
+// Lessons named in skip are not run (e.g. lessons whose output depends on the time of day).
+func Golden(t *testing.T, topic string, skip ...string) {
+	t.Helper()
+
+	tp := lesson.FindTopic(topic)
+	if tp == nil {
+		t.Fatalf("topic %q is not registered", topic)
+	}
+	if len(tp.Lessons) == 0 {
+		t.Fatalf("topic %q has no lessons", topic)
+	}
+
+	for _, l := range tp.Lessons {
+		t.Run(l.Name, func(t *testing.T) {
+			for _, s := range skip {
+				if s == l.Name {
+					t.Skip("output is not deterministic")
+				}
+			}
+			Compare(t, l)
+		})
+	}
+}
+
+// seed of the random source lessons get, so their output is the same on every run
+const Seed = 1
+
+// time on the clock lessons get: the Go playground's fixed time, a Tuesday evening. Like
+// the playground's, the clock moves forward when a lesson sleeps.
+var Time = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+// Compare runs a single lesson and compares its output with testdata/<name>.golden
+func Compare(t *testing.T, l lesson.Lesson) {
+	t.Helper()
+
+	c := clock.NewFake(Time)
+	c.AutoAdvance()
+	got := []byte(Run(l, c))
+
+	path := filepath.Join("testdata", l.Name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s output differs from %s:\n%s", l.ID(), path, diff(string(want), string(got)))
+	}
+}
+
+// Run runs a lesson with the seeded random source and the clock c and returns its output.
+// Lessons may print from several goroutines, and goroutines they leave running may keep
+// printing after Run returns; those lines are not in the result.
+func Run(l lesson.Lesson, c clock.Clock) string {
+	out := &syncBuffer{}
+	l.Run(lesson.Env{Out: out, Rand: rand.NewSource(Seed), Clock: c})
+	return out.String()
+}
+
+// buffer safe for concurrent use
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// report the first line that differs between want and got
+func diff(want, got string) string {
+	wantLines := strings.Split(want, "\n")
+	gotLines := strings.Split(got, "\n")
+	for i := 0; i < len(wantLines) || i < len(gotLines); i++ {
+		w, g := "<missing>", "<missing>"
+		if i < len(wantLines) {
+			w = wantLines[i]
+		}
+		if i < len(gotLines) {
+			g = gotLines[i]
+		}
+		if w != g {
+			return fmt.Sprintf("first difference at line %d:\n  want: %q\n  got:  %q", i+1, w, g)
+		}
+	}
+	return ""
+}
@@ -1,5 +1,5 @@
 // methods and interfaces
-package main
+package methods
 
 import (
 	"fmt"
@@ -8,6 +8,10 @@ import (
 	"math"
 	"strings"
 	"time"
+
+	"goTour/clock"
+	"goTour/lesson"
+	"goTour/vector"
 )
 
 // non-struct type declaration (can only have methods of types within the same package)
@@ -15,30 +19,24 @@ type MyFloat float64
 
 // methods have a special receiver argument
 // func <receiver> <functionName>() <returnType> {}
-func methods() {
-	basicsMethods()
-	pointerReceivers()
+func Methods(w io.Writer) {
+	basicsMethods(w)
+	pointerReceivers(w)
 }
 
-// vertex with (x,y) coordinates
-type Vertex struct {
-	X, Y float64
-}
+// vertex with (x,y) float64 coordinates. Its Abs and Scale methods are declared with
+// the generic vector.Vertex, the same type as the structs lesson's integer Vertex.
+type Vertex = vector.Vertex[float64]
 
 // calling methods
-func basicsMethods() {
+func basicsMethods(w io.Writer) {
 	// create vertex and compute value
-	v := Vertex{3, 4}
-	fmt.Println(v.Abs())
+	v := Vertex{X: 3, Y: 4}
+	fmt.Fprintln(w, v.Abs())
 
 	// absolute value of non-struct type
 	f := MyFloat(-math.Sqrt2) // convert float to MyFloat
-	fmt.Println(f.Abs())
-}
-
-// get Vertex square root of (X^2 + Y^2)
-func (v Vertex) Abs() float64 {
-	return math.Sqrt(v.X*v.X + v.Y*v.Y)
+	fmt.Fprintln(w, f.Abs())
 }
 
 // get the absolute value of f
@@ -53,33 +51,27 @@ func (f MyFloat) Abs() float64 {
 // methods can receive pointers (not for pointers to pointers)
 // can modify the value to which the receiver points
 // can send value or pointer to method (automatically converts)
-func pointerReceivers() {
+func pointerReceivers(w io.Writer) {
 	// send Vertex value
-	v := Vertex{3, 4}
+	v := Vertex{X: 3, Y: 4}
 	v.Scale(10)
-	fmt.Println(v.Abs())
+	fmt.Fprintln(w, v.Abs())
 
 	// send pointer to Vertex
-	p := &Vertex{4, 3}
+	p := &Vertex{X: 4, Y: 3}
 	p.Scale(3)
-	fmt.Println(p.Abs())
-}
-
-// receives pointer and modifies values to multiply with f
-func (v *Vertex) Scale(f float64) {
-	v.X = v.X * f
-	v.Y = v.Y * f
+	fmt.Fprintln(w, p.Abs())
 }
 
 // set of method signatures
 // type <interfaceName>er interface {}
-func interfaces() {
-	basicsInterfaces()
-	interfaceValues()
-	types()
-	keyExamples()
-	readers()
-	images()
+func Interfaces(w io.Writer, c clock.Clock) {
+	basicsInterfaces(w)
+	interfaceValues(w)
+	types(w)
+	keyExamples(w, c)
+	readers(w)
+	images(w)
 }
 
 // all values of type float64 automatically implements Abs()
@@ -88,27 +80,27 @@ type Abser interface {
 }
 
 // creating of interfaces
-func basicsInterfaces() {
+func basicsInterfaces(w io.Writer) {
 	var a Abser
 	f := MyFloat(-math.Sqrt2)
-	v := Vertex{3, 4}
+	v := Vertex{X: 3, Y: 4}
 
 	a = f // a MyFloat implements Abser
-	fmt.Println(a.Abs())
+	fmt.Fprintln(w, a.Abs())
 	a = &v // a *Vertex implements Abser
-	fmt.Println(a.Abs())
+	fmt.Fprintln(w, a.Abs())
 	// TODO: why does the below run? Find out why it shouldn't.
 	a = v // a Vertex does NOT implement Abser
-	fmt.Println(a.Abs())
+	fmt.Fprintln(w, a.Abs())
 
 	// implicit interface implementation
 	var i I = X{"hello"}
-	i.M()
+	i.M(w)
 }
 
 // all types can implement M()
 type I interface {
-	M()
+	M(w io.Writer)
 }
 
 // struct with type string S
@@ -122,98 +114,98 @@ type T struct {
 }
 
 // X implements interface I and thus implements M() (don't need to declare explicitly)
-func (x X) M() {
-	fmt.Println(x.S)
+func (x X) M(w io.Writer) {
+	fmt.Fprintln(w, x.S)
 }
 
 // T implements interface I and thus implements M()
-func (t *T) M() {
+func (t *T) M(w io.Writer) {
 	// should cater for underlying nil values
 	if t == nil {
-		fmt.Println("<nil>")
+		fmt.Fprintln(w, "<nil>")
 		return
 	}
-	fmt.Println(t.S)
+	fmt.Fprintln(w, t.S)
 }
 
 // MyFloat implements interface I and thus implements M()
-func (f MyFloat) M() {
-	fmt.Println(f)
+func (f MyFloat) M(w io.Writer) {
+	fmt.Fprintln(w, f)
 }
 
 // interface values tuple (<value>, <type>)
-func interfaceValues() {
+func interfaceValues(w io.Writer) {
 	// Calling a method on an interface value executes the method of the same name on its underlying type.
 	var i I
 	i = &T{"Hello"}
-	describe(i)
-	i.M()
+	describe(w, i)
+	i.M(w)
 
 	i = MyFloat(math.Pi)
-	describe(i)
-	i.M()
+	describe(w, i)
+	i.M(w)
 
 	// nil underlying values (note nil interface value causes runtime exception)
 	var t *T
 	i = t
-	describe(i)
-	i.M() // outputs <nil> due to explicit if statement catering for it
+	describe(w, i)
+	i.M(w) // outputs <nil> due to explicit if statement catering for it
 
 	// empty interface holds any value type (for unknown types)
 	var emptyInterface interface{}
-	describeUnknown(emptyInterface)
+	describeUnknown(w, emptyInterface)
 	emptyInterface = 42
-	describeUnknown(emptyInterface)
+	describeUnknown(w, emptyInterface)
 	emptyInterface = "hello"
-	describeUnknown(emptyInterface)
+	describeUnknown(w, emptyInterface)
 }
 
 // interface type assertions and switches
 // type assertion: access interface's underlying concrete value
 // type switch: permits several type assertions in series
-func types() {
+func types(w io.Writer) {
 	// type assertion
 	var i interface{} = "hello"
 	s := i.(string) // assert that i holds the concrete type string
-	fmt.Println(s)
+	fmt.Fprintln(w, s)
 	// test if interface holds a specific type
 	s, ok := i.(string) // (<underlyingValue>, <boolean>)
-	fmt.Println(s, ok)
+	fmt.Fprintln(w, s, ok)
 	f, ok := i.(float64)
-	fmt.Println(f, ok)
+	fmt.Fprintln(w, f, ok)
 	// f = i.(float64) // error since i does not hold a float64
-	// fmt.Println(f)
+	// fmt.Fprintln(w, f)
 
 	// type switches
-	typeSwitch(21)
-	typeSwitch("hello")
-	typeSwitch(true)
+	typeSwitch(w, 21)
+	typeSwitch(w, "hello")
+	typeSwitch(w, true)
 }
 
 // type switch - do different things depending on the type (unsure which type hence the empty interface parameter)
-func typeSwitch(i interface{}) {
+func typeSwitch(w io.Writer, i interface{}) {
 	switch v := i.(type) {
 	case int:
-		fmt.Printf("Twice %v is %v\n", v, v*2)
+		fmt.Fprintf(w, "Twice %v is %v\n", v, v*2)
 	case string:
-		fmt.Printf("%q is %v bytes long\n", v, len(v))
+		fmt.Fprintf(w, "%q is %v bytes long\n", v, len(v))
 	default:
-		fmt.Printf("I don't know about type %T!\n", v)
+		fmt.Fprintf(w, "I don't know about type %T!\n", v)
 	}
 }
 
 // stringer and error interfaces
-func keyExamples() {
-	stringers()
-	errors()
+func keyExamples(w io.Writer, c clock.Clock) {
+	stringers(w)
+	errors(w, c)
 }
 
 // ubiquotous interface Stringer defined by fmt
 // Stringer: type that can describe itself as a string
-func stringers() {
+func stringers(w io.Writer) {
 	h := Person{"Harry Potter", 22}
 	v := Person{"Tom Riddle", 90}
-	fmt.Println(h, v)
+	fmt.Fprintln(w, h, v)
 }
 
 // person with name and age
@@ -229,15 +221,17 @@ func (p Person) String() string {
 
 // ubiquotous interface Error defined by fmt
 // express error state
-func errors() {
+func errors(w io.Writer, c clock.Clock) {
 	// if the value returned by run is not nil, then print the value
-	if val := run(); val != nil {
-		fmt.Println(val)
+	if val := run(c); val != nil {
+		fmt.Fprintln(w, val)
 	}
 
 	// sqrt complex number error check
-	fmt.Println(Sqrt(2))
-	fmt.Println(Sqrt(-2))
+	z, err := Sqrt(w, 2)
+	fmt.Fprintln(w, z, err)
+	z, err = Sqrt(w, -2)
+	fmt.Fprintln(w, z, err)
 }
 
 // error with when and what
@@ -251,10 +245,10 @@ func (e *MyError) Error() string {
 	return fmt.Sprintf("at %v, %s", e.When, e.What)
 }
 
-// running function returns a MyError struct
-func run() error {
+// running function returns a MyError struct, stamped with the time on c
+func run(c clock.Clock) error {
 	return &MyError{
-		time.Now(),
+		c.Now(),
 		"it didn't work",
 	}
 }
@@ -268,7 +262,7 @@ func (e ErrNegativeSqrt) Error() string {
 }
 
 // Newton's method: compute sqrt using loop through guesses with error case
-func Sqrt(x float64) (float64, error) {
+func Sqrt(w io.Writer, x float64) (float64, error) {
 	if x < 0 {
 		return 0, ErrNegativeSqrt(x)
 	}
@@ -279,27 +273,27 @@ func Sqrt(x float64) (float64, error) {
 
 		// break if value not changing
 		if z == z_prev {
-			fmt.Println("Exiting loop")
+			fmt.Fprintln(w, "Exiting loop")
 			break
 		}
 		z_prev = z
-		fmt.Println(z)
+		fmt.Fprintln(w, z)
 	}
-	fmt.Printf("The sqrt of %g is ~%g\n", x, z)
+	fmt.Fprintf(w, "The sqrt of %g is ~%g\n", x, z)
 	return z, nil
 }
 
 // io.Reader interface (read stream of data)
-func readers() {
+func readers(w io.Writer) {
 	r := strings.NewReader("Hello, Reader")
 	b := make([]byte, 8)
 
 	for {
 		// Read returns the number of bytes populated and error value (nil if no err)
 		n, err := r.Read(b)
-		fmt.Printf("n = %v err = %v b = %v\n", n, err, b)
+		fmt.Fprintf(w, "n = %v err = %v b = %v\n", n, err, b)
 		// output value of bytes read
-		fmt.Printf("b[:n] = %q\n", b[:n])
+		fmt.Fprintf(w, "b[:n] = %q\n", b[:n])
 
 		// EOF error when the stream ends
 		if err == io.EOF {
@@ -308,24 +302,27 @@ func readers() {
 	}
 }
 
-func images() {
+func images(w io.Writer) {
 	m := image.NewRGBA(image.Rect(0, 0, 100, 100))
-	fmt.Println(m.Bounds())
-	fmt.Println(m.At(0, 0).RGBA())
+	fmt.Fprintln(w, m.Bounds())
+	r, g, b, a := m.At(0, 0).RGBA()
+	fmt.Fprintln(w, r, g, b, a)
 }
 
-func main() {
-	fmt.Println("Running methods & interfaces")
-	methods()
-	interfaces()
+func init() {
+	t := lesson.NewTopic(4, "methods", "Methods and interfaces")
+	t.Add("methods", "methods and pointer receivers", Methods)
+	t.AddEnv("interfaces", "interfaces, type switches, stringers, errors, readers and images", func(env lesson.Env) {
+		Interfaces(env.Out, env.Clock)
+	})
 }
 
 // output value and type for values of type I
-func describe(i I) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func describe(w io.Writer, i I) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
 
 // output value and type for values of unknown type
-func describeUnknown(i interface{}) {
-	fmt.Printf("(%v, %T)\n", i, i)
+func describeUnknown(w io.Writer, i interface{}) {
+	fmt.Fprintf(w, "(%v, %T)\n", i, i)
 }
@@ -0,0 +1,11 @@
+package methods
+
+import (
+	"testing"
+
+	"goTour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+	lessontest.Golden(t, "methods")
+}
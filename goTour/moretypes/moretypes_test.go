@@ -0,0 +1,60 @@
+package moretypes
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"goTour/geo"
+	"goTour/lesson/lessontest"
+)
+
+func TestGolden(t *testing.T) {
+	lessontest.Golden(t, "moretypes")
+}
+
+// the maps lesson's locations
+var locations = map[string]Vertex2{
+	"Bell Labs": {Lat: 40.68433, Long: -74.39967},
+	"Google":    {Lat: 37.42202, Long: -122.08408},
+}
+
+// the maps lesson's locations are a geo.Registry without converting them
+func TestVertex2Registry(t *testing.T) {
+	n, err := geo.Registry(locations).NearestTo("Google")
+	if err != nil || n.Name != "Bell Labs" {
+		t.Errorf("NearestTo(Google) = %+v, %v, want Bell Labs", n, err)
+	}
+}
+
+// and an index can be built from them
+func TestVertex2Index(t *testing.T) {
+	idx, err := geo.BuildIndex(locations, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Google is about 3,900 km from Bell Labs
+	near := idx.Within(locations["Bell Labs"], 100e3)
+	if len(near) != 1 || near[0].Name != "Bell Labs" {
+		t.Errorf("Within 100 km of Bell Labs = %+v, want Bell Labs only", near)
+	}
+	if all := idx.Nearest(locations["Bell Labs"], 2); len(all) != 2 || all[1].Name != "Google" {
+		t.Errorf("Nearest 2 to Bell Labs = %+v, want Bell Labs and Google", all)
+	}
+}
+
+// and they can be kept in files instead of a map literal
+func TestVertex2Files(t *testing.T) {
+	for _, name := range []string{"locations.csv", "locations.geojson"} {
+		path := filepath.Join(t.TempDir(), name)
+		if err := geo.Registry(locations).WriteFile(path); err != nil {
+			t.Fatal(err)
+		}
+		var mapLiteral map[string]Vertex2
+		var err error
+		mapLiteral, err = geo.ReadFile(path)
+		if err != nil || !reflect.DeepEqual(mapLiteral, locations) {
+			t.Errorf("%s: read %v, %v, want %v", name, mapLiteral, err, locations)
+		}
+	}
+}
@@ -1,79 +1,82 @@
 // more types: pointers, structs, arrays, slices, and maps
 
-package main
+package moretypes
 
 import (
 	"fmt"
+	"io"
 	"math"
 	"strings"
+
+	"goTour/geo"
+	"goTour/lesson"
+	"goTour/vector"
 )
 
-// struct: collection of fields
-type Vertex struct {
-	X int
-	Y int
-}
+// struct: collection of fields. An alias of vector.Vertex[int], so it has the vector
+// methods and prints like the tour's {1 2}.
+type Vertex = vector.Vertex[int]
 
 // struct literal
 var (
-	v1 = Vertex{1, 2}  // type Vertex
-	v2 = Vertex{X: 1}  // {1, 0}
-	v3 = Vertex{}      // {0, 0}
-	px = &Vertex{1, 2} // type *Vertex
+	v1 = Vertex{X: 1, Y: 2}  // type Vertex
+	v2 = Vertex{X: 1}        // {1, 0}
+	v3 = Vertex{}            // {0, 0}
+	px = &Vertex{X: 1, Y: 2} // type *Vertex
 )
 
 // pointers holds memory address value. Default value = nil. No pointer arithmetic.
-func pointers() {
+func Pointers(w io.Writer) {
 	i, j := 42, 2701
 
-	p := &i         // p points to i
-	fmt.Println(*p) // dereference p: read i's value
-	*p = 21         // set p's (i's) value to 21
-	fmt.Println(i)  // see new value of i
-	fmt.Println(*p)
+	p := &i             // p points to i
+	fmt.Fprintln(w, *p) // dereference p: read i's value
+	*p = 21             // set p's (i's) value to 21
+	fmt.Fprintln(w, i)  // see new value of i
+	fmt.Fprintln(w, *p)
 
-	p = &j         // p points to j
-	*p = *p / 37   // divide p (j) through 37
-	fmt.Println(j) // see new value of j
-	fmt.Println(*p)
+	p = &j             // p points to j
+	*p = *p / 37       // divide p (j) through 37
+	fmt.Fprintln(w, j) // see new value of j
+	fmt.Fprintln(w, *p)
 }
 
 // struct = collection of fields
-func structs() {
-	v := Vertex{1, 2}
-	fmt.Println(v)
+func Structs(w io.Writer) {
+	v := Vertex{X: 1, Y: 2}
+	fmt.Fprintln(w, v)
 
 	// change struct value
 	v.X = 4
-	fmt.Println(v)
+	fmt.Fprintln(w, v)
 
 	// pointers to structs
 	p := &v // p point to v
 	p.Y = 1e9
-	fmt.Println(v)
+	fmt.Fprintln(w, v)
 
 	// struct literal
-	fmt.Println(v1, v2, v3, px)
+	fmt.Fprintln(w, v1, v2, v3, px)
 }
 
 // Type [n]T is an array of n values of type T.
 // Arrays have a fixed size
-func arrays() {
+func Arrays(w io.Writer) {
 	var a [2]string // a = array of 2 strings. Can't resize.
 	a[0] = "Hello"
 	a[1] = "World"
-	fmt.Println(a[0], a[1])
-	fmt.Println(a)
+	fmt.Fprintln(w, a[0], a[1])
+	fmt.Fprintln(w, a)
 
 	primes := [6]int{2, 3, 4, 5, 666, 721} // int arr of size 6
-	fmt.Println(primes)
+	fmt.Fprintln(w, primes)
 }
 
 // Slices are dynamically sized, flexible view into an array
-func slices() {
+func Slices(w io.Writer) {
 	primes := [6]int{2, 3, 5, 7, 11, 13} // array
 	var s []int = primes[1:4]            // slice [3,5,7]
-	fmt.Println(s)
+	fmt.Fprintln(w, s)
 
 	// slice is like a pointer to a section in an array
 	names := [4]string{
@@ -82,48 +85,48 @@ func slices() {
 		"George",
 		"Ringo",
 	}
-	fmt.Println(names)
+	fmt.Fprintln(w, names)
 
 	a := names[0:2] // [John, Paul]
 	b := names[1:3] // [Paul, George]
 
 	// changing an element in a slice changes the corresponding array element
 	b[0] = "XXX"
-	fmt.Println(a, b) // [John, XXX], [XXX, George]
+	fmt.Fprintln(w, a, b) // [John, XXX], [XXX, George]
 
 	// Slice literals: an array without the length
-	sliceLiterals()
+	sliceLiterals(w)
 
 	// Slice default equivalent expressions
 	slice_eq := primes[0:6]
-	fmt.Println(slice_eq)
+	fmt.Fprintln(w, slice_eq)
 	slice_eq = primes[:6] // lower bound = 0
-	fmt.Println(slice_eq)
+	fmt.Fprintln(w, slice_eq)
 	slice_eq = primes[0:] // upper bound = slice length
-	fmt.Println(slice_eq)
+	fmt.Fprintln(w, slice_eq)
 	slice_eq = primes[:]
-	fmt.Println(slice_eq)
+	fmt.Fprintln(w, slice_eq)
 
 	// length and capacity
-	sliceLenCap()
+	sliceLenCap(w)
 
 	// dynamic slices: make
-	makeDynamicSlice()
+	makeDynamicSlice(w)
 
 	// slices of slices
-	sliceOfSlices()
+	sliceOfSlices(w)
 
 	// add to slice
-	sliceAppend()
+	sliceAppend(w)
 
 	// range returns (<elementIndex>, <elementValue>)
 	var pow = []int{1, 2, 4, 8, 16, 32, 64, 128}
 	for index, value := range pow {
-		fmt.Printf("2**%d = %d\n", index, value)
+		fmt.Fprintf(w, "2**%d = %d\n", index, value)
 	}
 	// skip range return value with _
 	for _, value := range pow {
-		fmt.Printf("%d\n", value)
+		fmt.Fprintf(w, "%d\n", value)
 	}
 	for index := range pow {
 		pow[index] = 1 << uint(index) // 2**<index>
@@ -132,12 +135,12 @@ func slices() {
 }
 
 // slice literals standard and struct types
-func sliceLiterals() {
+func sliceLiterals(w io.Writer) {
 	q := []int{2, 3, 5, 7, 11, 13}
-	fmt.Println(q)
+	fmt.Fprintln(w, q)
 
 	r := []bool{true, false, true, true, false, true}
-	fmt.Println(r)
+	fmt.Fprintln(w, r)
 
 	// slice of temporary structs
 	s := []struct {
@@ -151,52 +154,52 @@ func sliceLiterals() {
 		{11, false},
 		{13, true},
 	}
-	fmt.Println(s)
+	fmt.Fprintln(w, s)
 
 	// f()
 }
 
 // length = num elements in slice
 // capacity = num elements in underlying array (starting from 1st val in slice)
-func sliceLenCap() {
+func sliceLenCap(w io.Writer) {
 	// can change slice length through reslicing if have enough capacity
 	s := []int{2, 3, 5, 7, 11, 13}
-	printSlice(s)
+	printSlice(w, s)
 
 	// Slice the slice to give it zero length.
 	a := s[:0]
-	printSlice(a)
+	printSlice(w, a)
 
 	// Extend its length.
 	a = s[:4]
-	printSlice(a)
+	printSlice(w, a)
 
 	// Drop its first two values.
 	a = s[2:]
-	printSlice(a)
+	printSlice(w, a)
 
-	fmt.Println(s)
+	fmt.Fprintln(w, s)
 }
 
 // dynamically-sized arrays
 // make() allocates a zeroed array & returns a slice that refers to that array
-func makeDynamicSlice() {
+func makeDynamicSlice(w io.Writer) {
 	a := make([]int, 5) // len(a) = 5 (default capacity = len if not specified)
-	printSlice2("a", a)
+	printSlice2(w, "a", a)
 
 	b := make([]int, 0, 5) // len(b) = 0, cap(b) = 5
-	printSlice2("b", b)
+	printSlice2(w, "b", b)
 
 	c := b[:2] // len(c) = 2, cap(c) = cap(b) = 5
-	printSlice2("c", c)
+	printSlice2(w, "c", c)
 
 	d := c[2:5] // len(d) = 3, cap(d) = 3 (4-1) -> since upper unincluded
-	printSlice2("d", d)
+	printSlice2(w, "d", d)
 
 }
 
 // slice can contain any type (including other slices)
-func sliceOfSlices() {
+func sliceOfSlices(w io.Writer) {
 	// create tic-tac-toe board
 	board := [][]string{
 		[]string{"_", "_", "_"},
@@ -213,77 +216,75 @@ func sliceOfSlices() {
 
 	// print board
 	for i := 0; i < len(board); i++ {
-		fmt.Printf("%s\n", strings.Join(board[i], " "))
+		fmt.Fprintf(w, "%s\n", strings.Join(board[i], " "))
 	}
 }
 
 // add new elements to slice
 // If backing array of slice is too small to fit all the given values a bigger array will be allocated. The returned slice will point to the newly allocated array.
-func sliceAppend() {
+func sliceAppend(w io.Writer) {
 	// append(<slice>, <valuesToAppendToSlice>)
 
 	var s []int
-	printSlice(s)
+	printSlice(w, s)
 
 	// append individual elements
 	s = append(s, 0)
 	s = append(s, 1)
-	printSlice(s)
+	printSlice(w, s)
 
 	// append multiple elements
 	s = append(s, 2, 3, 4)
-	printSlice(s)
+	printSlice(w, s)
 }
 
-// map struct
-type Vertex2 struct {
-	Lat, Long float64
-}
+// map struct: an alias of geo.LatLong, so a map of them is a geo.Registry
+type Vertex2 = geo.LatLong
 
 // map variable
 var m map[string]Vertex2
 
 // maps: maps keys to values
-func maps() {
+func Maps(w io.Writer) {
 	m = make(map[string]Vertex2) // returns map of type string-to-Vertex2
 	m["Bell Labs"] = Vertex2{
-		40.68433, -74.39967,
+		Lat: 40.68433, Long: -74.39967,
 	}
-	fmt.Println(m["Bell Labs"])
+	fmt.Fprintln(w, m["Bell Labs"])
 
 	// map literals
 	mapLiteral := map[string]Vertex2{ // map string to Vertex2
 		"Bell Labs": {
-			40.68433, -74.39967,
+			Lat: 40.68433, Long: -74.39967,
 		},
 		"Google": {
-			37.42202, -122.08408,
+			Lat: 37.42202, Long: -122.08408,
 		},
 	}
-	fmt.Println(mapLiteral)
+	fmt.Fprintln(w, mapLiteral)
 
 	// mutating maps
-	mutatingMaps()
+	mutatingMaps(w)
 }
 
 // edit maps
-func mutatingMaps() {
+func mutatingMaps(w io.Writer) {
 	m := make(map[string]int)
 
 	// set, edit, and retrieve element
 	m["Answer"] = 42
-	fmt.Println("The value: ", m["Answer"])
+	fmt.Fprintln(w, "The value: ", m["Answer"])
 	m["Answer"] = 48
-	fmt.Println("The value: ", m["Answer"])
+	fmt.Fprintln(w, "The value: ", m["Answer"])
 
 	// delete(<map>, <key>)
 	delete(m, "Answer")
-	fmt.Println("The value: ", m["Answer"]) // 0 (false)
+	fmt.Fprintln(w, "The value: ", m["Answer"]) // 0 (false)
 
 	// test if key is present (<value>, <boolean>)
 	// if not present, value = 0
 	v, ok := m["Answer"]
-	fmt.Println("The value: ", v, "Present? ", ok)
+	fmt.Fprintln(w, "The value: ", v, "Present? ", ok)
 
 }
 
@@ -305,19 +306,19 @@ func compute(fn func(float64, float64) float64) float64 {
 }
 
 // functions may be used as function args and return values
-func functions() {
+func Functions(w io.Writer) {
 	hypot := func(x, y float64) float64 {
 		return math.Sqrt(x*x + y*y)
 	}
-	fmt.Println(hypot(5, 12)) // 13
+	fmt.Fprintln(w, hypot(5, 12)) // 13
 
-	fmt.Println(compute(hypot))    // compute(hypot(3, 4)) => Sqrt(3*3 + 4*4) = Sqrt(25) = 5
-	fmt.Println(compute(math.Pow)) // compute(math.Pow(3,4)) => 3**4 = 81
+	fmt.Fprintln(w, compute(hypot))    // compute(hypot(3, 4)) => Sqrt(3*3 + 4*4) = Sqrt(25) = 5
+	fmt.Fprintln(w, compute(math.Pow)) // compute(math.Pow(3,4)) => 3**4 = 81
 
 	// anonymous functions
 	pos, neg := anonymousFunction(), anonymousFunction()
 	for i := 0; i < 10; i++ {
-		fmt.Println(
+		fmt.Fprintln(w,
 			pos(i),
 			neg(-2*i),
 		)
@@ -333,25 +334,23 @@ func anonymousFunction() func(int) int { // returns anonymous func(int) int func
 	}
 }
 
-func main() {
-	fmt.Println("More types: pointers, structs, slices, and maps")
-
-	// pointers()
-	// structs()
-	// arrays()
-	// slices()
-	// maps()
-	functions()
-
+func init() {
+	t := lesson.NewTopic(3, "moretypes", "More types: pointers, structs, slices, and maps")
+	t.Add("pointers", "pointers and dereferencing", Pointers)
+	t.Add("structs", "structs, struct literals and pointers to structs", Structs)
+	t.Add("arrays", "fixed size arrays", Arrays)
+	t.Add("slices", "slices, length, capacity, make, append and range", Slices)
+	t.Add("maps", "maps, map literals and mutating maps", Maps)
+	t.Add("functions", "function values and closures", Functions)
 }
 
 // print slice with it's length and capacity values
-func printSlice(s []int) {
-	fmt.Printf("len=%d cap=%d %v\n", len(s), cap(s), s)
+func printSlice(w io.Writer, s []int) {
+	fmt.Fprintf(w, "len=%d cap=%d %v\n", len(s), cap(s), s)
 }
 
 // print slice with it's length and capacity values with var name
-func printSlice2(s string, x []int) {
-	fmt.Printf("%s len=%d cap=%d %v\n",
+func printSlice2(w io.Writer, s string, x []int) {
+	fmt.Fprintf(w, "%s len=%d cap=%d %v\n",
 		s, len(x), cap(x), x)
 }
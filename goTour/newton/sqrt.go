@@ -0,0 +1,145 @@
+// Package newton computes square roots with Newton's method and reports how the
+// iteration converged, generalising the tour's Sqrt exercise (z -= (z*z - x) / (2 * z)).
+// The step is written z = (z + x/z) / 2, the same step without squaring z, so it
+// doesn't overflow for huge x.
+package newton
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNaN is returned for the square root of NaN
+var ErrNaN = errors.New("newton: square root of NaN")
+
+// ErrDiverged is returned when a guess stops being a positive number, e.g. after a
+// custom starting guess far too small for x
+var ErrDiverged = errors.New("newton: iteration diverged")
+
+// iteration limit used when Options.MaxIter is 0
+const DefaultMaxIter = 100
+
+// sqrt error type (same as the tour's)
+type ErrNegativeSqrt float64
+
+// sqrt error string output
+func (e ErrNegativeSqrt) Error() string {
+	return fmt.Sprintf("cannot Sqrt negative number: %v", float64(e))
+}
+
+// starting guess strategy: returns the first guess for the square root of x (x > 0)
+type Guess func(x float64) float64
+
+// starting guesses
+var (
+	// x / 2 (used by the tour's Sqrt; slow for tiny and huge x)
+	HalfGuess Guess = func(x float64) float64 { return x / 2 }
+	// 1 (suggested by the tour exercise)
+	OneGuess Guess = func(float64) float64 { return 1 }
+	// x itself
+	XGuess Guess = func(x float64) float64 { return x }
+	// halve the binary exponent of x: within a factor of 2 of the root for any x
+	ExponentGuess Guess = func(x float64) float64 {
+		_, exp := math.Frexp(x)
+		return math.Ldexp(1, exp/2)
+	}
+)
+
+// a single Newton iteration
+type Step struct {
+	N     int     // iteration number, starting at 1
+	Z     float64 // new guess
+	Delta float64 // |Z - previous guess|
+}
+
+// square root settings. The zero value starts at ExponentGuess and stops once the
+// guesses stop improving.
+type Options struct {
+	// stop once Delta <= AbsTol or Delta <= RelTol*|Z|.
+	// When both are 0 the loop stops once a guess is no longer smaller than the previous
+	// one (after the first step Newton's method approaches the root from above), which
+	// also covers the tour's z == z_prev check without getting stuck flipping between
+	// two neighbouring floats.
+	AbsTol float64
+	RelTol float64
+
+	MaxIter int        // iteration limit (DefaultMaxIter if 0)
+	Guess   Guess      // starting guess strategy (ExponentGuess if nil)
+	OnStep  func(Step) // called after every iteration (optional)
+}
+
+// outcome of a square root calculation
+type Result struct {
+	Value      float64 // approximated square root
+	Iterations int     // number of Newton iterations performed
+	Error      float64 // Value - math.Sqrt(x)
+	Converged  bool    // stopped because of the tolerance rather than the iteration limit
+}
+
+// Sqrt approximates the square root of x with Newton's method.
+// Returns ErrNegativeSqrt for negative x, ErrNaN for NaN and ErrDiverged, with the result of
+// the last step, if a guess stops being a positive number.
+func Sqrt(x float64, opts Options) (Result, error) {
+	switch {
+	case x < 0:
+		return Result{}, ErrNegativeSqrt(x)
+	case x == 0 || math.IsInf(x, 1):
+		return Result{Value: x, Converged: true}, nil
+	case math.IsNaN(x):
+		return Result{Value: x}, ErrNaN
+	}
+
+	maxIter := opts.MaxIter
+	if maxIter <= 0 {
+		maxIter = DefaultMaxIter
+	}
+	guess := opts.Guess
+	if guess == nil {
+		guess = ExponentGuess
+	}
+
+	z := guess(x)
+	if !(z > 0) || math.IsInf(z, 1) {
+		return Result{}, fmt.Errorf("newton: starting guess %g for %g is not a positive number", z, x)
+	}
+
+	res := Result{}
+	for i := 1; i <= maxIter; i++ {
+		prev := z
+		z = (z + x/z) / 2
+		res.Iterations = i
+		if !(z > 0) || math.IsInf(z, 1) {
+			res.Value = z
+			res.Error = z - math.Sqrt(x)
+			return res, fmt.Errorf("%w: guess %g for %g at step %d", ErrDiverged, z, x, i)
+		}
+
+		delta := math.Abs(z - prev)
+		if opts.OnStep != nil {
+			opts.OnStep(Step{N: i, Z: z, Delta: delta})
+		}
+		var done bool
+		if z, done = converged(i, z, prev, opts); done {
+			res.Converged = true
+			break
+		}
+	}
+
+	res.Value = z
+	res.Error = z - math.Sqrt(x)
+	return res, nil
+}
+
+// check if the change from prev to z is within the tolerances (or if z stopped
+// improving when no tolerances are set); returns the guess to keep with the answer
+func converged(i int, z, prev float64, opts Options) (float64, bool) {
+	if opts.AbsTol == 0 && opts.RelTol == 0 {
+		if i > 1 && z > prev {
+			return prev, true // went back up: prev was the closer guess
+		}
+		return z, z == prev
+	}
+	delta := math.Abs(z - prev)
+	return z, delta <= opts.AbsTol || delta <= opts.RelTol*math.Abs(z)
+}
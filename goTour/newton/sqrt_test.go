@@ -0,0 +1,114 @@
+package newton
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// relative size of the last bit of a float64: Newton's method can end a bit either side
+// of the correctly rounded root
+const ulp = 2.3e-16
+
+func TestSqrt(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		opts Options
+		tol  float64 // largest |Error| / Sqrt(x) accepted
+	}{
+		{"two", 2, Options{}, ulp},
+		{"perfect square", 144, Options{}, 0},
+		{"tiny", 1e-300, Options{}, ulp},
+		{"subnormal", 1e-320, Options{}, ulp},
+		{"smallest", math.SmallestNonzeroFloat64, Options{}, 0},
+		{"huge", 1e300, Options{}, ulp},
+		{"huger", 1e308, Options{}, ulp},
+		{"largest", math.MaxFloat64, Options{}, ulp},
+		{"tour guess, huge", 1e308, Options{Guess: HalfGuess, MaxIter: 2000}, ulp},
+		{"tour guess, tiny", 1e-300, Options{Guess: HalfGuess, MaxIter: 2000}, ulp},
+		{"one guess", 1e10, Options{Guess: OneGuess}, ulp},
+		{"x guess", 0.25, Options{Guess: XGuess}, ulp},
+		{"absolute tolerance", 2, Options{AbsTol: 1e-6}, 1e-6},
+		{"relative tolerance", 1e200, Options{RelTol: 1e-9}, 1e-9},
+		{"both tolerances", 1e-200, Options{AbsTol: 1e-300, RelTol: 1e-12}, 1e-12},
+	}
+	for _, tt := range tests {
+		res, err := Sqrt(tt.x, tt.opts)
+		want := math.Sqrt(tt.x)
+		if err != nil || !res.Converged || math.Abs(res.Value-want) > tt.tol*want {
+			t.Errorf("%s: Sqrt(%g) = %+v, %v, want %g", tt.name, tt.x, res, err, want)
+			continue
+		}
+		if res.Error != res.Value-want {
+			t.Errorf("%s: Error = %g, want %g", tt.name, res.Error, res.Value-want)
+		}
+	}
+}
+
+func TestSqrtSpecial(t *testing.T) {
+	for _, x := range []float64{0, math.Inf(1)} {
+		if res, err := Sqrt(x, Options{}); err != nil || res.Value != x || !res.Converged {
+			t.Errorf("Sqrt(%g) = %+v, %v", x, res, err)
+		}
+	}
+	for _, x := range []float64{-2, -1e-320, math.Inf(-1)} {
+		var neg ErrNegativeSqrt
+		if _, err := Sqrt(x, Options{}); !errors.As(err, &neg) || float64(neg) != x {
+			t.Errorf("Sqrt(%g) returned %v, want ErrNegativeSqrt", x, err)
+		}
+	}
+	if _, err := Sqrt(-2, Options{}); err.Error() != "cannot Sqrt negative number: -2" {
+		t.Errorf("error message %q", err)
+	}
+	if res, err := Sqrt(math.NaN(), Options{}); !errors.Is(err, ErrNaN) || !math.IsNaN(res.Value) {
+		t.Errorf("Sqrt(NaN) = %+v, %v, want %v", res, err, ErrNaN)
+	}
+	// a guess this small makes x/z infinite
+	tooSmall := func(float64) float64 { return 1e-300 }
+	if r, err := Sqrt(1e300, Options{Guess: tooSmall}); !errors.Is(err, ErrDiverged) || r.Iterations != 1 || !math.IsInf(r.Error, 1) {
+		t.Errorf("Sqrt with a tiny guess returned %+v, %v, want %v after 1 step", r, err, ErrDiverged)
+	}
+	if _, err := Sqrt(2, Options{Guess: func(float64) float64 { return -1 }}); err == nil {
+		t.Error("Sqrt with a negative guess returned no error")
+	}
+}
+
+func TestSqrtIterationLimit(t *testing.T) {
+	res, err := Sqrt(1e300, Options{Guess: HalfGuess, MaxIter: 10})
+	if err != nil || res.Converged || res.Iterations != 10 {
+		t.Errorf("Sqrt with MaxIter 10 = %+v, %v, want 10 iterations without converging", res, err)
+	}
+}
+
+func TestOnStep(t *testing.T) {
+	var steps []Step
+	res, err := Sqrt(2, Options{Guess: OneGuess, OnStep: func(s Step) { steps = append(steps, s) }})
+	if err != nil || len(steps) != res.Iterations {
+		t.Fatalf("%d steps for %d iterations (err %v)", len(steps), res.Iterations, err)
+	}
+	// 1 -> 1.5 -> 1.41666...: each step numbered, closer than the last
+	if steps[0] != (Step{N: 1, Z: 1.5, Delta: 0.5}) {
+		t.Errorf("first step = %+v, want {1 1.5 0.5}", steps[0])
+	}
+	for i := 1; i < len(steps); i++ {
+		if steps[i].N != i+1 || steps[i].Z > steps[i-1].Z {
+			t.Errorf("step %+v after %+v", steps[i], steps[i-1])
+		}
+	}
+	if math.Abs(res.Value-math.Sqrt(2)) > ulp*math.Sqrt(2) {
+		t.Errorf("Sqrt(2) = %v, want %v", res.Value, math.Sqrt(2))
+	}
+}
+
+func TestConvergedKeepsBetterGuess(t *testing.T) {
+	// rounding can leave the last step one float above the previous guess; the previous
+	// guess is the answer then
+	z, ok := converged(3, 1.5000000000000002, 1.5, Options{})
+	if !ok || z != 1.5 {
+		t.Errorf("converged = %v, %v, want 1.5, true", z, ok)
+	}
+	if z, ok := converged(1, 2, 1, Options{}); ok || z != 2 {
+		t.Errorf("converged on the first step = %v, %v, want 2, false", z, ok)
+	}
+}
@@ -0,0 +1,136 @@
+// Package power raises numbers to integer powers without the surprises of the flow
+// control lesson's pow(x, n, lim): nothing is printed, integer results never silently
+// wrap unless asked to, and big.Int gives the exact value when nothing else fits.
+//
+//	Pow[int8](3, 5)        // -13 (243 wraps around, like Go's own arithmetic)
+//	Checked[int8](3, 5)    // 0, ErrOverflow
+//	Saturating[int8](3, 5) // 127
+//	Clamp(3, 5, 100)       // 100
+//	Exact(3, 500)          // every digit of 3^500
+package power
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"unsafe"
+)
+
+// ErrOverflow is returned when a power doesn't fit the result type
+var ErrOverflow = errors.New("power: result overflows")
+
+// any built-in integer type, or a type defined on one
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Pow returns x^n by repeated squaring (O(log n) multiplications). Like Go's own
+// arithmetic it wraps around on overflow; use Checked or Saturating to catch that.
+// Pow(x, 0) is 1 for every x, including 0.
+func Pow[T Integer](x T, n uint) T {
+	result := T(1)
+	for n > 0 {
+		if n&1 == 1 {
+			result *= x
+		}
+		n >>= 1
+		x *= x
+	}
+	return result
+}
+
+// Checked returns x^n, or ErrOverflow if the result doesn't fit T
+func Checked[T Integer](x T, n uint) (T, error) {
+	result := T(1)
+	for n > 0 {
+		var ok bool
+		if n&1 == 1 {
+			if result, ok = mul(result, x); !ok {
+				return 0, ErrOverflow
+			}
+		}
+		n >>= 1
+		// only square when another bit needs it: the square of the last base may not fit
+		// even though the result does (e.g. int8(2)^6 needs 2, 4 and 16 but not 256)
+		if n > 0 {
+			if x, ok = mul(x, x); !ok {
+				return 0, ErrOverflow
+			}
+		}
+	}
+	return result, nil
+}
+
+// Saturating returns x^n, clamped to the smallest or largest value of T if it doesn't fit
+func Saturating[T Integer](x T, n uint) T {
+	v, err := Checked(x, n)
+	if err == nil {
+		return v
+	}
+	lo, hi := bounds[T]()
+	if x < 0 && n&1 == 1 {
+		return lo
+	}
+	return hi
+}
+
+// Clamp returns x^n or lim, whichever is smaller, without overflowing: pow's clamping
+// for integers. Powers too large for T count as larger than any lim.
+func Clamp[T Integer](x T, n uint, lim T) T {
+	if v := Saturating(x, n); v < lim {
+		return v
+	}
+	return lim
+}
+
+// Float returns x^n, or lim if x^n >= lim, and whether it was clamped: pow's clamping
+// without the printing. Like pow, a NaN power is clamped to lim.
+func Float(x, n, lim float64) (v float64, clamped bool) {
+	if v := math.Pow(x, n); v < lim {
+		return v, false
+	}
+	return lim, true
+}
+
+// Exact returns x^n as a big.Int, which always holds the exact result
+func Exact[T Integer](x T, n uint) *big.Int {
+	var b *big.Int
+	if x < 0 {
+		b = big.NewInt(int64(x))
+	} else {
+		b = new(big.Int).SetUint64(uint64(x))
+	}
+	return Big(b, n)
+}
+
+// Big returns x^n as a new big.Int, leaving x unchanged
+func Big(x *big.Int, n uint) *big.Int {
+	return new(big.Int).Exp(x, new(big.Int).SetUint64(uint64(n)), nil)
+}
+
+// smallest and largest values of T
+func bounds[T Integer]() (lo, hi T) {
+	if ^T(0) > 0 { // unsigned: all ones is the largest value
+		return 0, ^T(0)
+	}
+	bits := unsafe.Sizeof(T(0)) * 8
+	hi = T(1)<<(bits-1) - 1
+	return -hi - 1, hi
+}
+
+// a*b and whether it fits T
+func mul[T Integer](a, b T) (T, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	p := a * b
+	if p/b != a {
+		return p, false
+	}
+	// the one case the division misses: lo * -1 wraps to lo and lo / -1 is lo again
+	if lo, _ := bounds[T](); (a == lo && b == ^T(0)) || (b == lo && a == ^T(0)) {
+		return p, false
+	}
+	return p, true
+}
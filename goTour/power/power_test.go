@@ -0,0 +1,185 @@
+package power
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestPow(t *testing.T) {
+	tests := []struct {
+		x    int64
+		n    uint
+		want int64
+	}{
+		{2, 10, 1024},
+		{0, 0, 1},
+		{0, 5, 0},
+		{-3, 3, -27},
+		{-1, 1001, -1},
+		{7, 1, 7},
+		{3, 39, 4052555153018976267},
+		{2, 63, math.MinInt64}, // wraps around
+		{2, 64, 0},
+	}
+	for _, tt := range tests {
+		if got := Pow(tt.x, tt.n); got != tt.want {
+			t.Errorf("Pow(%d, %d) = %d, want %d", tt.x, tt.n, got, tt.want)
+		}
+	}
+	if got := Pow[int8](3, 5); got != -13 {
+		t.Errorf("Pow[int8](3, 5) = %d, want -13", got)
+	}
+	if got := Pow[uint8](3, 5); got != 243 {
+		t.Errorf("Pow[uint8](3, 5) = %d, want 243", got)
+	}
+}
+
+func TestCheckedSaturating(t *testing.T) {
+	tests := []struct {
+		x         int8
+		n         uint
+		want      int8
+		overflows bool
+		saturated int8
+	}{
+		{2, 6, 64, false, 64},
+		{2, 7, 0, true, 127},
+		{-2, 7, -128, false, -128}, // the smallest int8 fits
+		{-2, 8, 0, true, 127},
+		{-2, 9, 0, true, -128},
+		{-128, 1, -128, false, -128},
+		{-128, 2, 0, true, 127},
+		{-128, 3, 0, true, -128},
+		{-1, 255, -1, false, -1},
+		{11, 2, 121, false, 121},
+		{12, 2, 0, true, 127},
+		{0, 0, 1, false, 1},
+		{127, 0, 1, false, 1},
+	}
+	for _, tt := range tests {
+		got, err := Checked(tt.x, tt.n)
+		if tt.overflows && !errors.Is(err, ErrOverflow) || !tt.overflows && (err != nil || got != tt.want) {
+			t.Errorf("Checked[int8](%d, %d) = %d, %v, want %d (overflows %v)", tt.x, tt.n, got, err, tt.want, tt.overflows)
+		}
+		if got := Saturating(tt.x, tt.n); got != tt.saturated {
+			t.Errorf("Saturating[int8](%d, %d) = %d, want %d", tt.x, tt.n, got, tt.saturated)
+		}
+	}
+
+	if got, err := Checked[uint8](2, 8); !errors.Is(err, ErrOverflow) {
+		t.Errorf("Checked[uint8](2, 8) = %d, %v, want %v", got, err, ErrOverflow)
+	}
+	if got := Saturating[uint8](2, 8); got != math.MaxUint8 {
+		t.Errorf("Saturating[uint8](2, 8) = %d, want 255", got)
+	}
+	if got, err := Checked[int64](-2, 63); got != math.MinInt64 || err != nil {
+		t.Errorf("Checked[int64](-2, 63) = %d, %v, want MinInt64", got, err)
+	}
+	if got := Saturating[int64](10, 19); got != math.MaxInt64 {
+		t.Errorf("Saturating[int64](10, 19) = %d, want MaxInt64", got)
+	}
+}
+
+// MinInt * -1 wraps to MinInt, which division alone doesn't catch
+func TestMulMinIntByMinusOne(t *testing.T) {
+	if _, ok := mul[int8](-128, -1); ok {
+		t.Error("mul[int8](-128, -1) fits")
+	}
+	if _, ok := mul[int8](-1, -128); ok {
+		t.Error("mul[int8](-1, -128) fits")
+	}
+	if _, ok := mul[int64](math.MinInt64, -1); ok {
+		t.Error("mul[int64](MinInt64, -1) fits")
+	}
+	if p, ok := mul[int8](-128, 1); !ok || p != -128 {
+		t.Errorf("mul[int8](-128, 1) = %d, %v", p, ok)
+	}
+	if p, ok := mul[uint8](255, 1); !ok || p != 255 {
+		t.Errorf("mul[uint8](255, 1) = %d, %v", p, ok)
+	}
+}
+
+// Checked agrees with Exact for every int8 and uint8 base
+func TestCheckedMatchesExact(t *testing.T) {
+	for x := math.MinInt8; x <= math.MaxInt8; x++ {
+		for n := uint(0); n <= 9; n++ {
+			exact := Exact(int8(x), n)
+			got, err := Checked(int8(x), n)
+			fits := exact.IsInt64() && exact.Int64() >= math.MinInt8 && exact.Int64() <= math.MaxInt8
+			if fits && (err != nil || int64(got) != exact.Int64()) || !fits && err == nil {
+				t.Errorf("Checked[int8](%d, %d) = %d, %v; exact value %v", x, n, got, err, exact)
+			}
+		}
+	}
+	for x := 0; x <= math.MaxUint8; x++ {
+		for n := uint(0); n <= 9; n++ {
+			exact := Exact(uint8(x), n)
+			got, err := Checked(uint8(x), n)
+			fits := exact.IsUint64() && exact.Uint64() <= math.MaxUint8
+			if fits && (err != nil || uint64(got) != exact.Uint64()) || !fits && err == nil {
+				t.Errorf("Checked[uint8](%d, %d) = %d, %v; exact value %v", x, n, got, err, exact)
+			}
+		}
+	}
+}
+
+func TestClampFloat(t *testing.T) {
+	tests := []struct {
+		x    int
+		n    uint
+		lim  int
+		want int
+	}{
+		{3, 2, 10, 9},
+		{3, 3, 10, 10},
+		{3, 5, 100, 100},
+		{10, 100, 5, 5}, // overflow counts as larger than any lim
+		{-10, 101, 5, math.MinInt},
+	}
+	for _, tt := range tests {
+		if got := Clamp(tt.x, tt.n, tt.lim); got != tt.want {
+			t.Errorf("Clamp(%d, %d, %d) = %d, want %d", tt.x, tt.n, tt.lim, got, tt.want)
+		}
+	}
+
+	floats := []struct {
+		x, n, lim float64
+		want      float64
+		clamped   bool
+	}{
+		{3, 2, 10, 9, false},
+		{3, 3, 20, 20, true},
+		{2, 0.5, 10, math.Sqrt2, false},
+		{2, 10, 1024, 1024, true},   // x^n == lim counts as clamped
+		{-8, 1.0 / 3, 10, 10, true}, // NaN
+	}
+	for _, tt := range floats {
+		if got, clamped := Float(tt.x, tt.n, tt.lim); got != tt.want || clamped != tt.clamped {
+			t.Errorf("Float(%v, %v, %v) = %v, %v, want %v, %v", tt.x, tt.n, tt.lim, got, clamped, tt.want, tt.clamped)
+		}
+	}
+}
+
+func TestExact(t *testing.T) {
+	want, _ := new(big.Int).SetString("1267650600228229401496703205376", 10)
+	if got := Exact(2, 100); got.Cmp(want) != 0 {
+		t.Errorf("Exact(2, 100) = %v, want %v", got, want)
+	}
+	if got := Exact[int8](-128, 3); got.Cmp(big.NewInt(-2097152)) != 0 {
+		t.Errorf("Exact[int8](-128, 3) = %v, want -2097152", got)
+	}
+	sq := Exact[uint64](math.MaxUint64, 2)
+	if want := new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), new(big.Int).SetUint64(math.MaxUint64)); sq.Cmp(want) != 0 {
+		t.Errorf("Exact(MaxUint64, 2) = %v, want %v", sq, want)
+	}
+
+	x := big.NewInt(7)
+	if got := Big(x, 3); got.Cmp(big.NewInt(343)) != 0 || x.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("Big(7, 3) = %v and left x = %v", got, x)
+	}
+	if got := Exact(0, 0); got.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("Exact(0, 0) = %v, want 1", got)
+	}
+}
@@ -0,0 +1,90 @@
+package roots
+
+import "math"
+
+// Brent finds a root of f in [a, b] with Brent's method: inverse quadratic interpolation
+// and secant steps while they make good progress, bisection when they don't.
+// Converges as reliably as bisection and usually almost as fast as the secant method.
+func Brent(f Func, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	fa, fb := f(a), f(b)
+	if r, ok := endpointRoot(a, fa, b, fb); ok {
+		return r, nil
+	}
+	if !bracketed(fa, fb) {
+		return Result{}, ErrNotBracketed
+	}
+
+	// b is the best guess so far, a the previous one and c the other end of the bracket
+	c, fc := b, fb
+	var d, e float64            // last step and the step before it
+	var width, residual float64 // half the bracket width and |f(b)| an iteration ago
+	for i := 1; i <= opts.MaxIter; i++ {
+		if !bracketed(fb, fc) {
+			// root is between a and b: move the far end of the bracket
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			// keep b as the point with the smallest |f|
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+
+		tol := 2*epsilon*math.Abs(b) + opts.tol(b)/2
+		mid := (c - b) / 2
+		if math.Abs(mid) <= tol || fb == 0 {
+			return Result{Root: b, Iterations: i, Converged: true}, nil
+		}
+
+		// interpolation crawls towards a multiple root ((x-1)^3 takes hundreds of steps):
+		// bisect when the last step neither halved the bracket nor cut |f(b)| fourfold,
+		// as fast (superlinear) convergence does
+		stalled := i > 1 && math.Abs(mid) > width/2 && math.Abs(fb) > residual/4
+		width, residual = math.Abs(mid), math.Abs(fb)
+
+		if !stalled && math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// try interpolation
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// secant
+				p = 2 * mid * s
+				q = 1 - s
+			} else {
+				// inverse quadratic interpolation
+				q = fa / fc
+				r := fb / fc
+				p = s * (2*mid*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			}
+			p = math.Abs(p)
+
+			// accept the interpolation if it falls within the bracket and the steps keep shrinking
+			if 2*p < math.Min(3*mid*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				d = mid
+				e = d
+			}
+		} else {
+			// bisection
+			d = mid
+			e = d
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else {
+			b += math.Copysign(tol, mid)
+		}
+		fb = f(b)
+	}
+	return Result{Root: b, Iterations: opts.MaxIter}, ErrNoConvergence
+}
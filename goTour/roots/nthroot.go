@@ -0,0 +1,77 @@
+package roots
+
+import (
+	"fmt"
+	"math"
+)
+
+// nth root of a negative number error (same idea as the tour's ErrNegativeSqrt)
+type ErrNegativeRoot struct {
+	X float64
+	N int
+}
+
+// nth root error string output
+func (e ErrNegativeRoot) Error() string {
+	return fmt.Sprintf("cannot take even root %d of negative number: %v", e.N, e.X)
+}
+
+// NthRoot returns the real nth root of x (n >= 1).
+// Odd roots of negative numbers are negative; even roots of negative numbers return ErrNegativeRoot.
+func NthRoot(x float64, n int) (float64, error) {
+	switch {
+	case n < 1:
+		return math.NaN(), fmt.Errorf("roots: cannot take root %d, n must be at least 1", n)
+	case x < 0 && n%2 == 0:
+		return math.NaN(), ErrNegativeRoot{X: x, N: n}
+	case x < 0:
+		z, err := NthRoot(-x, n)
+		return -z, err
+	case n == 1 || x == 0 || math.IsInf(x, 1) || math.IsNaN(x):
+		return x, nil
+	}
+
+	// scale x = frac * 2^exp (0.5 <= frac < 1) to y * 2^(q*n) with y = frac * 2^(exp - q*n)
+	// in [0.5, 2^n): the root is then 2^q times the root of y, which lies in [0.5, 2]
+	frac, exp := math.Frexp(x)
+	q := floorDiv(exp, n)
+	y := math.Ldexp(frac, exp-q*n)
+
+	f := func(z float64) float64 { return pow(z, n) - y }
+	df := func(z float64) float64 { return float64(n) * pow(z, n-1) }
+	if math.IsInf(y, 1) {
+		// n is too big for y to fit in a float64: solve n*log(z) = log(y) instead
+		logY := math.Log(frac) + float64(exp-q*n)*math.Ln2
+		f = func(z float64) float64 { return float64(n)*math.Log(z) - logY }
+		df = func(z float64) float64 { return float64(n) / z }
+	}
+	r, err := NewtonBracketed(f, df, 0.5, 2, Options{})
+	return math.Ldexp(r.Root, q), err
+}
+
+// a / b rounded towards negative infinity (b > 0)
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+	return q
+}
+
+// Cbrt returns the cube root of x, or ErrNoConvergence from NthRoot
+func Cbrt(x float64) (float64, error) {
+	return NthRoot(x, 3)
+}
+
+// z^n for n >= 0 by repeated squaring
+func pow(z float64, n int) float64 {
+	result := 1.0
+	for n > 0 {
+		if n&1 == 1 {
+			result *= z
+		}
+		z *= z
+		n >>= 1
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+// Package roots finds roots of arbitrary functions, generalising the Newton loop of the
+// tour's Sqrt exercise (z -= (z*z - x) / (2 * z)) from f(z) = z*z - x to any f.
+//
+// Newton and Secant only need starting points (Newton falls back to NewtonBracketed
+// once its guesses land on both sides of the root); Bisection, Brent and NewtonBracketed
+// need an interval [a, b] where f(a) and f(b) have opposite signs.
+package roots
+
+import (
+	"errors"
+	"math"
+)
+
+// iteration limit used when Options.MaxIter is 0
+const DefaultMaxIter = 100
+
+// errors returned by the root finders
+var (
+	ErrNotBracketed   = errors.New("roots: f(a) and f(b) do not have opposite signs")
+	ErrZeroDerivative = errors.New("roots: derivative is zero")
+	ErrDiverged       = errors.New("roots: iteration diverged")
+	ErrNoConvergence  = errors.New("roots: no convergence within the iteration limit")
+)
+
+// function of one variable
+type Func func(x float64) float64
+
+// root finder settings. The zero value stops once the step size is within a few ulps
+// of the root (or f(root) == 0) and allows DefaultMaxIter iterations.
+type Options struct {
+	// stop once a step (or half the bracket width) is <= AbsTol + RelTol*|x|
+	AbsTol  float64
+	RelTol  float64
+	MaxIter int // iteration limit (DefaultMaxIter if 0)
+}
+
+// outcome of a root search
+type Result struct {
+	Root       float64
+	Iterations int
+	Converged  bool
+}
+
+// fill in the default iteration limit and tolerance
+func (o Options) withDefaults() Options {
+	if o.MaxIter <= 0 {
+		o.MaxIter = DefaultMaxIter
+	}
+	if o.AbsTol == 0 && o.RelTol == 0 {
+		o.RelTol = 4 * epsilon
+	}
+	return o
+}
+
+// step size tolerance at x
+func (o Options) tol(x float64) float64 {
+	return o.AbsTol + o.RelTol*math.Abs(x)
+}
+
+// float64 machine epsilon
+const epsilon = 2.220446049250313e-16
+
+// Newton finds a root of f with Newton's method, starting at x0. df is the derivative of f.
+// It remembers the last guesses on either side of the root, and once it has a pair
+// falls back to NewtonBracketed between them (with its own iteration limit) if it lands
+// on a flat spot, |f| stops shrinking or it runs out of iterations. Without a pair it
+// returns ErrZeroDerivative, ErrDiverged or ErrNoConvergence.
+func Newton(f, df Func, x0 float64, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	var b bracket
+	x, fx := x0, f(x0)
+	b.add(x, fx)
+	for i := 1; i <= opts.MaxIter; i++ {
+		if fx == 0 {
+			return Result{Root: x, Iterations: i - 1, Converged: true}, nil
+		}
+		d := df(x)
+		if d == 0 {
+			return b.fallback(f, df, Result{Root: x, Iterations: i - 1}, ErrZeroDerivative, opts)
+		}
+
+		step := fx / d
+		next := x - step
+		if math.IsNaN(next) || math.IsInf(next, 0) {
+			return b.fallback(f, df, Result{Root: next, Iterations: i}, ErrDiverged, opts)
+		}
+		fnext := f(next)
+		b.add(next, fnext)
+		if math.Abs(step) <= opts.tol(next) {
+			return Result{Root: next, Iterations: i, Converged: true}, nil
+		}
+		if b.ok() && !(math.Abs(fnext) < math.Abs(fx)) {
+			// overshooting back and forth across the root
+			return b.fallback(f, df, Result{Root: next, Iterations: i}, ErrDiverged, opts)
+		}
+		x, fx = next, fnext
+	}
+	return b.fallback(f, df, Result{Root: x, Iterations: opts.MaxIter}, ErrNoConvergence, opts)
+}
+
+// last guesses seen with f below and above zero
+type bracket struct {
+	neg, pos       float64
+	hasNeg, hasPos bool
+}
+
+// remember x if f(x) = fx is finite and nonzero
+func (b *bracket) add(x, fx float64) {
+	switch {
+	case fx < 0 && !math.IsInf(fx, 0):
+		b.neg, b.hasNeg = x, true
+	case fx > 0 && !math.IsInf(fx, 0):
+		b.pos, b.hasPos = x, true
+	}
+}
+
+// check if the root is known to lie between two guesses
+func (b *bracket) ok() bool {
+	return b.hasNeg && b.hasPos
+}
+
+// finish a failed Newton search with NewtonBracketed if there is a bracket, counting the
+// iterations of both; otherwise return failed and err
+func (b *bracket) fallback(f, df Func, failed Result, err error, opts Options) (Result, error) {
+	if !b.ok() {
+		return failed, err
+	}
+	r, err := NewtonBracketed(f, df, b.neg, b.pos, opts)
+	r.Iterations += failed.Iterations
+	return r, err
+}
+
+// Secant finds a root of f with the secant method: Newton's method with the derivative
+// replaced by the slope through the last two guesses, starting with x0 and x1.
+func Secant(f Func, x0, x1 float64, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	f0, f1 := f(x0), f(x1)
+	for i := 1; i <= opts.MaxIter; i++ {
+		if f1 == 0 {
+			return Result{Root: x1, Iterations: i - 1, Converged: true}, nil
+		}
+		if f1 == f0 {
+			return Result{Root: x1, Iterations: i - 1}, ErrZeroDerivative
+		}
+
+		step := f1 * (x1 - x0) / (f1 - f0)
+		x0, f0 = x1, f1
+		x1 -= step
+		if math.IsNaN(x1) || math.IsInf(x1, 0) {
+			return Result{Root: x1, Iterations: i}, ErrDiverged
+		}
+		if math.Abs(step) <= opts.tol(x1) {
+			return Result{Root: x1, Iterations: i, Converged: true}, nil
+		}
+		f1 = f(x1)
+	}
+	return Result{Root: x1, Iterations: opts.MaxIter}, ErrNoConvergence
+}
+
+// Bisection finds a root of f in [a, b] by repeatedly halving the interval.
+// Slow but always converges if f is continuous and f(a), f(b) have opposite signs.
+func Bisection(f Func, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	fa, fb := f(a), f(b)
+	if r, ok := endpointRoot(a, fa, b, fb); ok {
+		return r, nil
+	}
+	if !bracketed(fa, fb) {
+		return Result{}, ErrNotBracketed
+	}
+
+	for i := 1; i <= opts.MaxIter; i++ {
+		mid := a + (b-a)/2
+		fm := f(mid)
+		// stop when the root is found, the interval is small enough or can't be split any further
+		if fm == 0 || math.Abs(b-a)/2 <= opts.tol(mid) || mid == a || mid == b {
+			return Result{Root: mid, Iterations: i, Converged: true}, nil
+		}
+		if bracketed(fa, fm) {
+			b = mid
+		} else {
+			a, fa = mid, fm
+		}
+	}
+	return Result{Root: a + (b-a)/2, Iterations: opts.MaxIter}, ErrNoConvergence
+}
+
+// NewtonBracketed finds a root of f in [a, b] with Newton's method, falling back to a
+// bisection step whenever the Newton step would leave the bracket, isn't shrinking fast
+// enough (divergence) or the derivative is zero.
+func NewtonBracketed(f, df Func, a, b float64, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	fa, fb := f(a), f(b)
+	if r, ok := endpointRoot(a, fa, b, fb); ok {
+		return r, nil
+	}
+	if !bracketed(fa, fb) {
+		return Result{}, ErrNotBracketed
+	}
+
+	// orient the bracket so that f(lo) < 0 < f(hi)
+	lo, hi := a, b
+	if fa > 0 {
+		lo, hi = b, a
+	}
+
+	x := a + (b-a)/2
+	dxOld := math.Abs(b - a)
+	dx := dxOld
+	fx, d := f(x), df(x)
+	for i := 1; i <= opts.MaxIter; i++ {
+		outside := ((x-hi)*d-fx)*((x-lo)*d-fx) > 0 // also true when d == 0
+		slow := math.Abs(2*fx) > math.Abs(dxOld*d)
+		overflow := math.IsInf(fx, 0) || math.IsInf(d, 0) || math.IsNaN(fx) || math.IsNaN(d)
+		if outside || slow || overflow {
+			// bisection step
+			dxOld, dx = dx, (hi-lo)/2
+			x = lo + dx
+			if x == lo {
+				return Result{Root: x, Iterations: i, Converged: true}, nil
+			}
+		} else {
+			// Newton step
+			dxOld, dx = dx, fx/d
+			prev := x
+			x -= dx
+			if x == prev {
+				return Result{Root: x, Iterations: i, Converged: true}, nil
+			}
+		}
+		if math.Abs(dx) <= opts.tol(x) {
+			return Result{Root: x, Iterations: i, Converged: true}, nil
+		}
+
+		fx, d = f(x), df(x)
+		if fx == 0 {
+			return Result{Root: x, Iterations: i, Converged: true}, nil
+		}
+		if fx < 0 {
+			lo = x
+		} else {
+			hi = x
+		}
+	}
+	return Result{Root: x, Iterations: opts.MaxIter}, ErrNoConvergence
+}
+
+// check if fa and fb have opposite signs
+func bracketed(fa, fb float64) bool {
+	return (fa < 0 && fb > 0) || (fa > 0 && fb < 0)
+}
+
+// result for a root sitting exactly on one end of the bracket
+func endpointRoot(a, fa, b, fb float64) (Result, bool) {
+	switch {
+	case fa == 0:
+		return Result{Root: a, Converged: true}, true
+	case fb == 0:
+		return Result{Root: b, Converged: true}, true
+	}
+	return Result{}, false
+}
@@ -0,0 +1,149 @@
+package roots
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// test functions with their derivatives, a bracket and a starting point
+var functions = []struct {
+	name     string
+	f, df    Func
+	a, b     float64 // f(a) and f(b) have opposite signs
+	x0       float64 // where Newton converges from
+	root     float64
+	multiple bool // the secant method crawls towards it
+}{
+	{"x²-2", func(x float64) float64 { return x*x - 2 }, func(x float64) float64 { return 2 * x }, 0, 2, 1, math.Sqrt2, false},
+	{"cos x - x", func(x float64) float64 { return math.Cos(x) - x }, func(x float64) float64 { return -math.Sin(x) - 1 }, 0, 1, 0.5, 0.7390851332151607, false},
+	{"eˣ-10", func(x float64) float64 { return math.Exp(x) - 10 }, math.Exp, 0, 5, 1, math.Ln10, false},
+	{"x³-2x-5", func(x float64) float64 { return x*x*x - 2*x - 5 }, func(x float64) float64 { return 3*x*x - 2 }, 2, 3, 2, 2.0945514815423265, false},
+	{"(x-1)³", func(x float64) float64 { y := x - 1; return y * y * y }, func(x float64) float64 { return 3 * (x - 1) * (x - 1) }, -10, 11, 3, 1, true},
+}
+
+func TestSolvers(t *testing.T) {
+	solvers := []struct {
+		name   string
+		solve  func(f, df Func, a, b, x0 float64) (Result, error)
+		tol    float64 // root accuracy: Newton's method only finds a triple root to about 1e-6
+		simple bool    // only for simple roots
+	}{
+		{"Newton", func(f, df Func, _, _, x0 float64) (Result, error) { return Newton(f, df, x0, Options{}) }, 1e-5, false},
+		{"Secant", func(f, _ Func, a, b, x0 float64) (Result, error) { return Secant(f, x0, x0+0.1, Options{}) }, 1e-5, true},
+		{"Bisection", func(f, _ Func, a, b, _ float64) (Result, error) { return Bisection(f, a, b, Options{}) }, 1e-14, false},
+		{"Brent", func(f, _ Func, a, b, _ float64) (Result, error) { return Brent(f, a, b, Options{}) }, 1e-14, false},
+		{"NewtonBracketed", func(f, df Func, a, b, _ float64) (Result, error) { return NewtonBracketed(f, df, a, b, Options{}) }, 1e-5, false},
+	}
+	for _, s := range solvers {
+		for _, fn := range functions {
+			if s.simple && fn.multiple {
+				continue
+			}
+			r, err := s.solve(fn.f, fn.df, fn.a, fn.b, fn.x0)
+			if err != nil || !r.Converged || math.Abs(r.Root-fn.root) > s.tol*math.Max(1, math.Abs(fn.root)) {
+				t.Errorf("%s(%s) = %+v, %v, want %v", s.name, fn.name, r, err, fn.root)
+			}
+		}
+	}
+}
+
+func TestBrentMultipleRoot(t *testing.T) {
+	// interpolation alone crawls towards a triple root: this used to run out of iterations
+	f := func(x float64) float64 { y := x - 1; return y * y * y }
+	r, err := Brent(f, -10, 11, Options{})
+	if err != nil || math.Abs(r.Root-1) > 1e-15 || r.Iterations > 90 {
+		t.Errorf("Brent((x-1)³) = %+v, %v, want 1 within 90 iterations", r, err)
+	}
+	// simple roots still converge superlinearly
+	r, err = Brent(functions[0].f, 0, 2, Options{})
+	if err != nil || r.Iterations > 12 {
+		t.Errorf("Brent(x²-2) = %+v, %v, want at most 12 iterations", r, err)
+	}
+}
+
+func TestNewtonFallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		f, df Func
+		x0    float64
+	}{
+		// each step overshoots further: 2.5, -0.69, 3.32, -4.11, ...
+		{"atan", func(x float64) float64 { return math.Atan(x - 1) }, func(x float64) float64 { return 1 / (1 + (x-1)*(x-1)) }, 2.5},
+		// Newton doubles the distance to the root every step
+		{"cbrt", func(x float64) float64 { return math.Cbrt(x - 1) }, func(x float64) float64 { return 1 / (3 * math.Cbrt((x-1)*(x-1))) }, 2},
+	}
+	for _, tt := range tests {
+		r, err := Newton(tt.f, tt.df, tt.x0, Options{})
+		if err != nil || !r.Converged || math.Abs(r.Root-1) > 1e-12 {
+			t.Errorf("Newton(%s, %v) = %+v, %v, want 1", tt.name, tt.x0, r, err)
+		}
+	}
+
+	// no bracket to fall back to
+	flat := func(x float64) float64 { return x*x + 1 }
+	if _, err := Newton(flat, func(x float64) float64 { return 2 * x }, 0, Options{}); !errors.Is(err, ErrZeroDerivative) {
+		t.Errorf("Newton(x²+1, 0) returned %v, want %v", err, ErrZeroDerivative)
+	}
+	// x³-2x+2 cycles between 0 and 1 from 0, never crossing the root near -1.77
+	cycle := func(x float64) float64 { return x*x*x - 2*x + 2 }
+	_, err := Newton(cycle, func(x float64) float64 { return 3*x*x - 2 }, 0, Options{MaxIter: 20})
+	if !errors.Is(err, ErrNoConvergence) {
+		t.Errorf("Newton on a cycle returned %v, want %v", err, ErrNoConvergence)
+	}
+}
+
+func TestBracketErrors(t *testing.T) {
+	f := functions[0].f
+	if _, err := Bisection(f, 2, 3, Options{}); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Bisection without a bracket returned %v", err)
+	}
+	if _, err := Brent(f, -1, 1, Options{}); !errors.Is(err, ErrNotBracketed) {
+		t.Errorf("Brent without a bracket returned %v", err)
+	}
+	four := func(x float64) float64 { return x*x - 4 }
+	if r, err := Brent(four, 2, 3, Options{}); err != nil || r.Root != 2 || r.Iterations != 0 {
+		t.Errorf("Brent with the root at an end = %+v, %v", r, err)
+	}
+	if r, err := Bisection(f, 0, 2, Options{MaxIter: 5}); !errors.Is(err, ErrNoConvergence) || r.Iterations != 5 {
+		t.Errorf("Bisection with MaxIter 5 = %+v, %v", r, err)
+	}
+	if r, err := Bisection(f, 0, 2, Options{AbsTol: 0.01}); err != nil || math.Abs(r.Root-math.Sqrt2) > 0.01 {
+		t.Errorf("Bisection with AbsTol 0.01 = %+v, %v", r, err)
+	}
+}
+
+func TestNthRoot(t *testing.T) {
+	tests := []struct {
+		x    float64
+		n    int
+		want float64
+	}{
+		{27, 3, 3},
+		{-27, 3, -3},
+		{2, 2, math.Sqrt2},
+		{1024, 10, 2},
+		{1e300, 2, 1e150},
+		{1e-300, 3, 1e-100},
+		{math.MaxFloat64, 1000, math.Pow(math.MaxFloat64, 1.0/1000)},
+		{5, 1, 5},
+		{0, 7, 0},
+	}
+	for _, tt := range tests {
+		got, err := NthRoot(tt.x, tt.n)
+		if err != nil || math.Abs(got-tt.want) > 4e-16*math.Abs(tt.want) {
+			t.Errorf("NthRoot(%g, %d) = %v, %v, want %v", tt.x, tt.n, got, err, tt.want)
+		}
+	}
+
+	var neg ErrNegativeRoot
+	if _, err := NthRoot(-16, 4); !errors.As(err, &neg) || neg != (ErrNegativeRoot{-16, 4}) {
+		t.Errorf("NthRoot(-16, 4) returned %v", err)
+	}
+	if _, err := NthRoot(8, 0); err == nil {
+		t.Error("NthRoot(8, 0) returned no error")
+	}
+	if got, err := Cbrt(-8); got != -2 || err != nil {
+		t.Errorf("Cbrt(-8) = %v, %v", got, err)
+	}
+}
@@ -0,0 +1,190 @@
+// Package sysinfo reports what the flow control lesson's switch on runtime.GOOS only
+// hints at: the platform, Go version, CPUs, goroutines and memory of the running program,
+// plus the module build information (VCS revision, dependency versions) for bug reports.
+package sysinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"text/tabwriter"
+)
+
+// snapshot of the running program
+type Info struct {
+	GOOS         string `json:"goos"`
+	GOARCH       string `json:"goarch"`
+	GoVersion    string `json:"goVersion"`
+	NumCPU       int    `json:"numCPU"`
+	GOMAXPROCS   int    `json:"gomaxprocs"`
+	NumGoroutine int    `json:"numGoroutine"`
+	Memory       Memory `json:"memory"`
+	Build        *Build `json:"build,omitempty"` // nil if the binary has no build info
+}
+
+// memory statistics from runtime.MemStats, in bytes
+type Memory struct {
+	Alloc      uint64 `json:"alloc"`      // allocated heap objects
+	TotalAlloc uint64 `json:"totalAlloc"` // allocated over the program's lifetime
+	Sys        uint64 `json:"sys"`        // obtained from the OS
+	HeapInuse  uint64 `json:"heapInuse"`
+	StackInuse uint64 `json:"stackInuse"`
+	NumGC      uint32 `json:"numGC"` // completed garbage collections
+}
+
+// module build information from debug.ReadBuildInfo
+type Build struct {
+	GoVersion string   `json:"goVersion"` // Go version the binary was built with
+	Path      string   `json:"path"`      // main package path, e.g. goTour/cmd/sysinfo
+	Main      Module   `json:"main"`
+	VCS       string   `json:"vcs,omitempty"` // e.g. git, empty for go run and tests
+	Revision  string   `json:"revision,omitempty"`
+	Time      string   `json:"time,omitempty"` // commit time
+	Modified  bool     `json:"modified"`       // uncommitted changes in the working tree
+	Deps      []Module `json:"deps,omitempty"`
+}
+
+// module version, e.g. fyne.io/fyne/v2 v2.1.0
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	Replace string `json:"replace,omitempty"` // path (and version) it is replaced with
+}
+
+// Collect takes a snapshot of the running program
+func Collect() Info {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	info := Info{
+		GOOS:         runtime.GOOS,
+		GOARCH:       runtime.GOARCH,
+		GoVersion:    runtime.Version(),
+		NumCPU:       runtime.NumCPU(),
+		GOMAXPROCS:   runtime.GOMAXPROCS(0),
+		NumGoroutine: runtime.NumGoroutine(),
+		Memory: Memory{
+			Alloc:      m.Alloc,
+			TotalAlloc: m.TotalAlloc,
+			Sys:        m.Sys,
+			HeapInuse:  m.HeapInuse,
+			StackInuse: m.StackInuse,
+			NumGC:      m.NumGC,
+		},
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Build = newBuild(bi)
+	}
+	return info
+}
+
+// convert debug.BuildInfo
+func newBuild(bi *debug.BuildInfo) *Build {
+	b := &Build{GoVersion: bi.GoVersion, Path: bi.Path, Main: newModule(&bi.Main)}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs":
+			b.VCS = s.Value
+		case "vcs.revision":
+			b.Revision = s.Value
+		case "vcs.time":
+			b.Time = s.Value
+		case "vcs.modified":
+			b.Modified = s.Value == "true"
+		}
+	}
+	for _, d := range bi.Deps {
+		b.Deps = append(b.Deps, newModule(d))
+	}
+	return b
+}
+
+// convert debug.Module
+func newModule(m *debug.Module) Module {
+	mod := Module{Path: m.Path, Version: m.Version}
+	if m.Replace != nil {
+		mod.Replace = m.Replace.Path
+		if m.Replace.Version != "" {
+			mod.Replace += " " + m.Replace.Version
+		}
+	}
+	return mod
+}
+
+// Dep returns the dependency with the given module path, e.g. "fyne.io/fyne/v2"
+func (b *Build) Dep(path string) (Module, bool) {
+	if b == nil {
+		return Module{}, false
+	}
+	for _, d := range b.Deps {
+		if d.Path == path {
+			return d, true
+		}
+	}
+	return Module{}, false
+}
+
+// String formats the module as "path version", plus "=> replacement" if replaced
+func (m Module) String() string {
+	s := m.Path + " " + m.Version
+	if m.Replace != "" {
+		s += " => " + m.Replace
+	}
+	return s
+}
+
+// WriteText writes the snapshot as an aligned plain text report, e.g. to paste into
+// a bug report
+func (info Info) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "go version:\t%s\n", info.GoVersion)
+	fmt.Fprintf(tw, "os/arch:\t%s/%s\n", info.GOOS, info.GOARCH)
+	fmt.Fprintf(tw, "cpus:\t%d (GOMAXPROCS %d)\n", info.NumCPU, info.GOMAXPROCS)
+	fmt.Fprintf(tw, "goroutines:\t%d\n", info.NumGoroutine)
+	m := info.Memory
+	fmt.Fprintf(tw, "memory:\t%s allocated, %s total, %s from the OS\n", Bytes(m.Alloc), Bytes(m.TotalAlloc), Bytes(m.Sys))
+	fmt.Fprintf(tw, "\t%s heap in use, %s stack in use, %d GC runs\n", Bytes(m.HeapInuse), Bytes(m.StackInuse), m.NumGC)
+
+	if b := info.Build; b != nil {
+		fmt.Fprintf(tw, "main module:\t%s\n", b.Main)
+		fmt.Fprintf(tw, "package:\t%s\n", b.Path)
+		if b.VCS != "" {
+			modified := ""
+			if b.Modified {
+				modified = " (modified)"
+			}
+			fmt.Fprintf(tw, "%s revision:\t%s%s %s\n", b.VCS, b.Revision, modified, b.Time)
+		}
+		for i, d := range b.Deps {
+			label := ""
+			if i == 0 {
+				label = "dependencies:"
+			}
+			fmt.Fprintf(tw, "%s\t%s\n", label, d)
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the snapshot as indented JSON
+func (info Info) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(info)
+}
+
+// Bytes formats a byte count with a binary unit, e.g. 1.5 MiB
+func Bytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
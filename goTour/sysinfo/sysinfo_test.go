@@ -0,0 +1,175 @@
+package sysinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	tests := []struct {
+		n    uint64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{1<<20 - 1, "1024.0 KiB"},
+		{1 << 20, "1.0 MiB"},
+		{5 << 30, "5.0 GiB"},
+		{1 << 60, "1.0 EiB"},
+		{1<<64 - 1, "16.0 EiB"},
+	}
+	for _, tt := range tests {
+		if got := Bytes(tt.n); got != tt.want {
+			t.Errorf("Bytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+// build information as the go command records it for a git checkout
+var buildInfo = &debug.BuildInfo{
+	GoVersion: "go1.23.0",
+	Path:      "goTour/cmd/sysinfo",
+	Main:      debug.Module{Path: "goTour", Version: "(devel)"},
+	Deps: []*debug.Module{
+		{Path: "fyne.io/fyne/v2", Version: "v2.1.0"},
+		{Path: "goTour", Version: "v0.0.0", Replace: &debug.Module{Path: "../goTour"}},
+		{Path: "golang.org/x/text", Version: "v0.3.0", Replace: &debug.Module{Path: "golang.org/x/text", Version: "v0.3.8"}},
+	},
+	Settings: []debug.BuildSetting{
+		{Key: "-compiler", Value: "gc"},
+		{Key: "vcs", Value: "git"},
+		{Key: "vcs.revision", Value: "0492118"},
+		{Key: "vcs.time", Value: "2026-10-18T07:00:44Z"},
+		{Key: "vcs.modified", Value: "true"},
+	},
+}
+
+func TestNewBuild(t *testing.T) {
+	want := &Build{
+		GoVersion: "go1.23.0",
+		Path:      "goTour/cmd/sysinfo",
+		Main:      Module{Path: "goTour", Version: "(devel)"},
+		VCS:       "git",
+		Revision:  "0492118",
+		Time:      "2026-10-18T07:00:44Z",
+		Modified:  true,
+		Deps: []Module{
+			{Path: "fyne.io/fyne/v2", Version: "v2.1.0"},
+			{Path: "goTour", Version: "v0.0.0", Replace: "../goTour"},
+			{Path: "golang.org/x/text", Version: "v0.3.0", Replace: "golang.org/x/text v0.3.8"},
+		},
+	}
+	b := newBuild(buildInfo)
+	if !reflect.DeepEqual(b, want) {
+		t.Fatalf("newBuild =\n%+v\nwant\n%+v", b, want)
+	}
+
+	tests := []struct {
+		path string
+		want string // String() of the dependency, "" if there is none
+	}{
+		{"fyne.io/fyne/v2", "fyne.io/fyne/v2 v2.1.0"},
+		{"goTour", "goTour v0.0.0 => ../goTour"},
+		{"golang.org/x/text", "golang.org/x/text v0.3.0 => golang.org/x/text v0.3.8"},
+		{"fyne.io/fyne", ""},
+	}
+	for _, tt := range tests {
+		d, ok := b.Dep(tt.path)
+		if ok != (tt.want != "") || ok && d.String() != tt.want {
+			t.Errorf("Dep(%q) = %v, %v, want %q", tt.path, d, ok, tt.want)
+		}
+	}
+	var none *Build
+	if _, ok := none.Dep("goTour"); ok {
+		t.Error("Dep on a nil Build found a module")
+	}
+}
+
+// snapshot with fixed numbers, so the report is the same everywhere
+var info = Info{
+	GOOS:         "linux",
+	GOARCH:       "amd64",
+	GoVersion:    "go1.23.0",
+	NumCPU:       8,
+	GOMAXPROCS:   4,
+	NumGoroutine: 3,
+	Memory:       Memory{Alloc: 1536, TotalAlloc: 3 << 20, Sys: 12 << 20, HeapInuse: 2048, StackInuse: 512, NumGC: 2},
+	Build:        newBuild(buildInfo),
+}
+
+func TestWriteText(t *testing.T) {
+	want := `go version:    go1.23.0
+os/arch:       linux/amd64
+cpus:          8 (GOMAXPROCS 4)
+goroutines:    3
+memory:        1.5 KiB allocated, 3.0 MiB total, 12.0 MiB from the OS
+               2.0 KiB heap in use, 512 B stack in use, 2 GC runs
+main module:   goTour (devel)
+package:       goTour/cmd/sysinfo
+git revision:  0492118 (modified) 2026-10-18T07:00:44Z
+dependencies:  fyne.io/fyne/v2 v2.1.0
+               goTour v0.0.0 => ../goTour
+               golang.org/x/text v0.3.0 => golang.org/x/text v0.3.8
+`
+	var buf bytes.Buffer
+	if err := info.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("WriteText wrote\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// no build information, as in binaries built without module support
+	noBuild := info
+	noBuild.Build = nil
+	buf.Reset()
+	if err := noBuild.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if s := buf.String(); strings.Contains(s, "main module") || !strings.HasSuffix(s, "2 GC runs\n") {
+		t.Errorf("WriteText without build information wrote\n%s", s)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := info.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got Info
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, info) {
+		t.Errorf("WriteJSON round trip = %+v, want %+v", got, info)
+	}
+	for _, key := range []string{`"goos": "linux"`, `"numCPU": 8`, `"replace": "../goTour"`, `"modified": true`} {
+		if !strings.Contains(buf.String(), key) {
+			t.Errorf("WriteJSON output has no %s:\n%s", key, buf.String())
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	info := Collect()
+	if info.GOOS != runtime.GOOS || info.GOARCH != runtime.GOARCH || info.GoVersion != runtime.Version() {
+		t.Errorf("Collect() platform = %s/%s %s", info.GOOS, info.GOARCH, info.GoVersion)
+	}
+	if info.NumCPU < 1 || info.GOMAXPROCS < 1 || info.NumGoroutine < 1 {
+		t.Errorf("Collect() = %d CPUs, GOMAXPROCS %d, %d goroutines", info.NumCPU, info.GOMAXPROCS, info.NumGoroutine)
+	}
+	if m := info.Memory; m.Alloc == 0 || m.Sys < m.HeapInuse || m.TotalAlloc < m.Alloc {
+		t.Errorf("Collect() memory = %+v", m)
+	}
+	// test binaries have build information naming this module
+	if info.Build == nil || info.Build.Main.Path != "goTour" {
+		t.Errorf("Collect() build = %+v", info.Build)
+	}
+}
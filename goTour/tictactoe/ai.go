@@ -0,0 +1,412 @@
+package tictactoe
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"goTour/clock"
+)
+
+// how well the AI plays
+type Difficulty int
+
+const (
+	Easy    Difficulty = iota // looks one move ahead and often plays at random
+	Medium                    // looks two moves ahead and sometimes plays at random
+	Hard                      // looks four moves ahead
+	Perfect                   // searches as deep as its time budget allows
+)
+
+// difficulty names as used by the commands' flags
+func (d Difficulty) String() string {
+	switch d {
+	case Easy:
+		return "easy"
+	case Medium:
+		return "medium"
+	case Hard:
+		return "hard"
+	case Perfect:
+		return "perfect"
+	}
+	return fmt.Sprintf("Difficulty(%d)", int(d))
+}
+
+// ParseDifficulty returns the difficulty with the given name (easy, medium, hard, perfect)
+func ParseDifficulty(s string) (Difficulty, error) {
+	for d := Easy; d <= Perfect; d++ {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("tictactoe: unknown difficulty %q (easy, medium, hard or perfect)", s)
+}
+
+// time an AI spends on a move unless told otherwise
+const DefaultBudget = time.Second
+
+// AI is a computer player using minimax with alpha-beta pruning. Each move is found by
+// iterative deepening (searching 1, 2, 3... moves ahead) until Depth or the time budget
+// is reached, reusing earlier iterations through a transposition table. Create it with
+// NewAI and adjust the fields for settings between the difficulties.
+type AI struct {
+	Depth   int           // moves to look ahead (0 for no limit)
+	Budget  time.Duration // time per move (0 for no limit)
+	Blunder float64       // chance of playing a random move instead of searching
+	Clock   clock.Clock   // clock the budget is measured on (clock.Real if nil)
+	rand    *rand.Rand
+}
+
+// NewAI returns an AI playing at the given difficulty, drawing random moves from src
+func NewAI(d Difficulty, src rand.Source) *AI {
+	ai := &AI{Budget: DefaultBudget, Clock: clock.Real, rand: rand.New(src)}
+	switch d {
+	case Easy:
+		ai.Depth, ai.Blunder = 1, 0.5
+	case Medium:
+		ai.Depth, ai.Blunder = 2, 0.2
+	case Hard:
+		ai.Depth = 4
+	}
+	return ai
+}
+
+// Move returns the AI's move for the player to move in g
+func (ai *AI) Move(g *Game) (Move, error) {
+	legal := g.Legal()
+	if len(legal) == 0 {
+		return Move{}, ErrGameOver
+	}
+	if ai.Blunder > 0 && ai.rand.Float64() < ai.Blunder {
+		return legal[ai.rand.Intn(len(legal))], nil
+	}
+
+	s := newSearch(g)
+	maxDepth := len(legal)
+	if ai.Depth > 0 && ai.Depth < maxDepth {
+		maxDepth = ai.Depth
+	}
+	c := ai.Clock
+	if c == nil {
+		c = clock.Real
+	}
+	start := c.Now()
+
+	// the first iteration always completes so there is a move to return
+	best, score := s.root(1)
+	if ai.Budget > 0 {
+		s.clock = c
+		s.deadline = start.Add(ai.Budget)
+	}
+	for depth := 2; depth <= maxDepth && !decided(score); depth++ {
+		m, sc := s.root(depth)
+		if s.aborted {
+			break
+		}
+		best, score = m, sc
+	}
+	return s.move(best), nil
+}
+
+// search scores
+const (
+	win      = 1 << 30 // score of a win on the next move; quicker wins score higher
+	infinity = win + 1
+)
+
+// check if a score is a forced win or loss, so deeper searches can't change the move
+func decided(score int) bool {
+	return score > win/2 || score < -win/2
+}
+
+// transposition table entry: the result of searching a position depth moves deep
+type entry struct {
+	depth int
+	score int
+	bound int8 // exact, lower or upper
+	best  int  // best cell found, -1 if none
+}
+
+// entry bounds
+const (
+	exact int8 = iota
+	lower      // score is at least entry.score (the search was cut off by beta)
+	upper      // score is at most entry.score (no move raised alpha)
+)
+
+// position being searched, as flat cells with an incrementally updated Zobrist hash
+type search struct {
+	rules   Rules
+	cells   []int8 // 0 empty, 1 X, 2 O, row by row
+	empty   int
+	turn    int8 // player to move at the root
+	hash    uint64
+	zobrist [][2]uint64 // random bits per cell and player
+	order   []int       // cells nearest the centre first
+	windows [][]int     // every run of K cells, for the evaluation
+	table   map[uint64]entry
+
+	clock    clock.Clock
+	deadline time.Time
+	nodes    int
+	aborted  bool
+}
+
+// set up a search of g's position
+func newSearch(g *Game) *search {
+	r := g.rules
+	s := &search{
+		rules: r,
+		cells: make([]int8, r.M*r.N),
+		turn:  1,
+		table: make(map[uint64]entry),
+	}
+	if g.Turn() == O {
+		s.turn = 2
+	}
+
+	// fixed seed: the same position always hashes the same
+	rnd := rand.New(rand.NewSource(int64(r.M*MaxSize*MaxSize + r.N*MaxSize + r.K)))
+	s.zobrist = make([][2]uint64, len(s.cells))
+	for i := range s.zobrist {
+		s.zobrist[i] = [2]uint64{rnd.Uint64(), rnd.Uint64()}
+	}
+	for row := range g.board {
+		for col, c := range g.board[row] {
+			i := row*r.N + col
+			switch c {
+			case X:
+				s.place(i, 1)
+			case O:
+				s.place(i, 2)
+			default:
+				s.empty++
+			}
+			s.order = append(s.order, i)
+		}
+	}
+
+	// centre cells take part in more lines, so try them first
+	dist := func(i int) float64 {
+		dr, dc := float64(i/r.N)-float64(r.M-1)/2, float64(i%r.N)-float64(r.N-1)/2
+		return dr*dr + dc*dc
+	}
+	sort.SliceStable(s.order, func(a, b int) bool { return dist(s.order[a]) < dist(s.order[b]) })
+
+	for row := 0; row < r.M; row++ {
+		for col := 0; col < r.N; col++ {
+			for _, d := range directions {
+				endR, endC := row+(r.K-1)*d.Row, col+(r.K-1)*d.Col
+				if endR < 0 || endR >= r.M || endC < 0 || endC >= r.N {
+					continue
+				}
+				w := make([]int, r.K)
+				for j := range w {
+					w[j] = (row+j*d.Row)*r.N + col + j*d.Col
+				}
+				s.windows = append(s.windows, w)
+			}
+		}
+	}
+	return s
+}
+
+// cell index as a Move
+func (s *search) move(i int) Move {
+	return Move{i / s.rules.N, i % s.rules.N}
+}
+
+// put player's mark in cell i
+func (s *search) place(i int, player int8) {
+	s.cells[i] = player
+	s.hash ^= s.zobrist[i][player-1]
+}
+
+// clear cell i, which holds player's mark
+func (s *search) remove(i int, player int8) {
+	s.cells[i] = 0
+	s.hash ^= s.zobrist[i][player-1]
+}
+
+// check if the mark in cell i completes a line of K
+func (s *search) wins(i int, player int8) bool {
+	r := s.rules
+	row, col := i/r.N, i%r.N
+	for _, d := range directions {
+		n := 1
+		for _, sign := range []int{1, -1} {
+			rr, cc := row+sign*d.Row, col+sign*d.Col
+			for rr >= 0 && rr < r.M && cc >= 0 && cc < r.N && s.cells[rr*r.N+cc] == player {
+				n++
+				rr, cc = rr+sign*d.Row, cc+sign*d.Col
+			}
+		}
+		if n >= r.K {
+			return true
+		}
+	}
+	return false
+}
+
+// search every move at the root to the given depth, returning the best cell and its score
+func (s *search) root(depth int) (int, int) {
+	best, bestScore := -1, -infinity
+	alpha := -infinity
+	for _, i := range s.moves(s.table[s.hash].best, s.table[s.hash].depth > 0) {
+		score := s.score(i, s.turn, depth, 0, alpha, infinity)
+		if s.aborted {
+			break
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+		if score > alpha {
+			alpha = score
+		}
+	}
+	s.table[s.hash] = entry{depth: depth, score: bestScore, bound: exact, best: best}
+	return best, bestScore
+}
+
+// score of player moving to cell i, searching depth moves deep in total
+func (s *search) score(i int, player int8, depth, ply, alpha, beta int) int {
+	s.place(i, player)
+	s.empty--
+	defer func() {
+		s.remove(i, player)
+		s.empty++
+	}()
+	switch {
+	case s.wins(i, player):
+		return win - ply
+	case s.empty == 0:
+		return 0
+	}
+	return -s.negamax(3-player, depth-1, ply+1, -beta, -alpha)
+}
+
+// minimax in negamax form: the best score player can reach from the current position,
+// from player's point of view, within the alpha-beta window
+func (s *search) negamax(player int8, depth, ply, alpha, beta int) int {
+	if s.nodes++; s.nodes&1023 == 0 && s.clock != nil && s.clock.Now().After(s.deadline) {
+		s.aborted = true
+	}
+	if s.aborted {
+		return 0
+	}
+	if depth == 0 {
+		return s.evaluate(player)
+	}
+
+	// positions reached by different move orders are only searched once; win scores
+	// are stored relative to this position
+	e, found := s.table[s.hash]
+	if found && e.depth >= depth {
+		score := fromTable(e.score, ply)
+		switch {
+		case e.bound == exact:
+			return score
+		case e.bound == lower && score >= beta:
+			return score
+		case e.bound == upper && score <= alpha:
+			return score
+		}
+	}
+
+	alphaOrig := alpha
+	best, bestScore := -1, -infinity
+	for _, i := range s.moves(e.best, found) {
+		score := s.score(i, player, depth, ply, alpha, beta)
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+		if score > alpha {
+			alpha = score
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if s.aborted {
+		return 0
+	}
+
+	bound := exact
+	switch {
+	case bestScore <= alphaOrig:
+		bound = upper
+	case bestScore >= beta:
+		bound = lower
+	}
+	s.table[s.hash] = entry{depth: depth, score: toTable(bestScore, ply), bound: bound, best: best}
+	return bestScore
+}
+
+// empty cells, the table's best move first and then nearest the centre first
+func (s *search) moves(first int, useFirst bool) []int {
+	moves := make([]int, 0, s.empty)
+	if useFirst && first >= 0 && s.cells[first] == 0 {
+		moves = append(moves, first)
+	}
+	for _, i := range s.order {
+		if s.cells[i] == 0 && !(useFirst && i == first) {
+			moves = append(moves, i)
+		}
+	}
+	return moves
+}
+
+// heuristic score for player when the search stops before the end of the game: lines
+// only one player can still complete count for that player, more so the fuller they are
+func (s *search) evaluate(player int8) int {
+	score := 0
+	for _, w := range s.windows {
+		var counts [3]int
+		for _, i := range w {
+			counts[s.cells[i]]++
+		}
+		switch {
+		case counts[1] > 0 && counts[2] == 0:
+			score += weight(counts[1])
+		case counts[2] > 0 && counts[1] == 0:
+			score -= weight(counts[2])
+		}
+	}
+	if player == 2 {
+		return -score
+	}
+	return score
+}
+
+// value of a line with n marks: each mark makes it worth 4 times as much
+func weight(n int) int {
+	if n > 10 {
+		n = 10
+	}
+	return 1 << (2 * n)
+}
+
+// win scores count down with the distance from the root; the table stores them counted
+// from the position itself so they can be reused at another ply
+func toTable(score, ply int) int {
+	switch {
+	case score > win/2:
+		return score + ply
+	case score < -win/2:
+		return score - ply
+	}
+	return score
+}
+
+// inverse of toTable
+func fromTable(score, ply int) int {
+	switch {
+	case score > win/2:
+		return score - ply
+	case score < -win/2:
+		return score + ply
+	}
+	return score
+}
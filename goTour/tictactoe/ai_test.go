@@ -0,0 +1,239 @@
+package tictactoe
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"goTour/clock"
+)
+
+func TestDifficulty(t *testing.T) {
+	for d := Easy; d <= Perfect; d++ {
+		if got, err := ParseDifficulty(d.String()); got != d || err != nil {
+			t.Errorf("ParseDifficulty(%q) = %v, %v", d.String(), got, err)
+		}
+	}
+	if _, err := ParseDifficulty("impossible"); err == nil {
+		t.Error("ParseDifficulty(impossible) succeeded")
+	}
+	if s := Difficulty(9).String(); s != "Difficulty(9)" {
+		t.Errorf("Difficulty(9).String() = %q", s)
+	}
+}
+
+// value of the position for the player to move with perfect play from both sides:
+// 1 for a win, 0 for a draw and -1 for a loss
+func solve(g *Game, values map[string]int) int {
+	key := g.Encode()
+	if v, ok := values[key]; ok {
+		return v
+	}
+	v := -1
+	switch g.Status() {
+	case Draw:
+		v = 0
+	case InProgress:
+		for _, m := range g.Legal() {
+			g.Play(m)
+			v = max(v, -solve(g, values))
+			g.Undo()
+		}
+	}
+	// otherwise the player who just moved has won
+	values[key] = v
+	return v
+}
+
+// every position reachable from g with the player to move, by Encode
+func positions(g *Game, seen map[string]bool) {
+	if seen[g.Encode()] || g.Status() != InProgress {
+		return
+	}
+	seen[g.Encode()] = true
+	for _, m := range g.Legal() {
+		g.Play(m)
+		positions(g, seen)
+		g.Undo()
+	}
+}
+
+// the perfect AI plays a move keeping the best result in every reachable position
+func TestPerfectAIIsOptimal(t *testing.T) {
+	values := make(map[string]int)
+	seen := make(map[string]bool)
+	positions(NewGame(), seen)
+	if len(seen) != 4520 {
+		t.Fatalf("found %d positions to move from, want 4520", len(seen))
+	}
+
+	ai := NewAI(Perfect, rand.NewSource(1))
+	ai.Budget = 0
+	for pos := range seen {
+		g, err := Load(pos)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := solve(g, values)
+		m, err := ai.Move(g)
+		if err != nil {
+			t.Fatalf("%s: %v", pos, err)
+		}
+		if err := g.Play(m); err != nil {
+			t.Fatalf("%s: AI played %v: %v", pos, m, err)
+		}
+		if got := -solve(g, values); got != want {
+			t.Errorf("%s: AI played %v worth %d, the position is worth %d", pos, m, got, want)
+		}
+	}
+}
+
+func TestAITactics(t *testing.T) {
+	tests := []struct {
+		name     string
+		position string
+		want     Move
+	}{
+		{"win now", "XX_/OO_/___", Move{0, 2}},
+		{"win now rather than block", "XX_/OO_/X__", Move{1, 2}},
+		{"block", "X__/OO_/X__", Move{1, 2}},
+		{"block the diagonal", "X__/_X_/O__", Move{2, 2}},
+		{"win on a bigger board", "4:____/_XXX/O_O_/O___", Move{1, 0}},
+	}
+	for _, d := range []Difficulty{Hard, Perfect} {
+		for _, tt := range tests {
+			g, err := Load(tt.position)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ai := NewAI(d, rand.NewSource(1))
+			ai.Budget = 0
+			if m, err := ai.Move(g); m != tt.want || err != nil {
+				t.Errorf("%v, %s (%s): Move() = %v, %v, want %v", d, tt.name, tt.position, m, err, tt.want)
+			}
+		}
+	}
+}
+
+func TestAIBlunders(t *testing.T) {
+	ai := NewAI(Easy, rand.NewSource(1))
+	ai.Blunder = 1 // always random
+	g, _ := Load("XX_/OO_/___")
+	moves := make(map[Move]int)
+	for i := 0; i < 200; i++ {
+		m, err := ai.Move(g)
+		if err != nil || g.Valid(m) != nil {
+			t.Fatalf("random Move() = %v, %v", m, err)
+		}
+		moves[m]++
+	}
+	if len(moves) != len(g.Legal()) {
+		t.Errorf("random moves %v don't cover all %d legal moves", moves, len(g.Legal()))
+	}
+
+	// the same seed gives the same moves
+	a, b := NewAI(Medium, rand.NewSource(7)), NewAI(Medium, rand.NewSource(7))
+	for i := 0; i < 20; i++ {
+		ma, _ := a.Move(NewGame())
+		mb, _ := b.Move(NewGame())
+		if ma != mb {
+			t.Fatalf("move %d: %v and %v from the same seed", i, ma, mb)
+		}
+	}
+
+	done, _ := Load("XXX/OO_/___")
+	if _, err := ai.Move(done); err != ErrGameOver {
+		t.Errorf("Move() on a finished game = %v, want %v", err, ErrGameOver)
+	}
+}
+
+// clock that moves an hour forward every time it is read
+type hastyClock struct {
+	*clock.Fake
+	reads int
+}
+
+func (c *hastyClock) Now() time.Time {
+	c.reads++
+	c.Fake.Advance(time.Hour)
+	return c.Fake.Now()
+}
+
+func TestAIBudget(t *testing.T) {
+	g, err := NewGameWith(Rules{7, 7, 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &hastyClock{Fake: clock.NewFake(time.Date(2009, 11, 10, 23, 0, 0, 0, time.UTC))}
+	ai := NewAI(Perfect, rand.NewSource(1))
+	ai.Budget = time.Second
+	ai.Clock = c
+	// the whole board would take far too long: the search stops at the first check of
+	// the clock and plays the best move found so far
+	m, err := ai.Move(g)
+	if err != nil || g.Valid(m) != nil {
+		t.Fatalf("Move() = %v, %v", m, err)
+	}
+	if c.reads != 2 {
+		t.Errorf("clock read %d times, want 2 (start and the first check)", c.reads)
+	}
+	if m != (Move{3, 3}) {
+		t.Errorf("Move() = %v, want the centre", m)
+	}
+}
+
+// hashes kept up to date move by move match hashes of the positions set up from
+// scratch, and no two 3x3 positions share one
+func TestZobrist(t *testing.T) {
+	seen := make(map[string]bool)
+	positions(NewGame(), seen)
+	hashes := make(map[uint64]string)
+	for pos := range seen {
+		g, _ := Load(pos)
+		s := newSearch(g)
+		if other, dup := hashes[s.hash]; dup {
+			t.Errorf("%s and %s have the same hash", pos, other)
+		}
+		hashes[s.hash] = pos
+
+		// play each move and take it back
+		for _, m := range g.Legal() {
+			i := m.Row*3 + m.Col
+			before := s.hash
+			s.place(i, s.turn)
+			g.Play(m)
+			if want := newSearch(g).hash; s.hash != want {
+				t.Errorf("%s after %v: hash %x, want %x", pos, m, s.hash, want)
+			}
+			g.Undo()
+			s.remove(i, s.turn)
+			if s.hash != before {
+				t.Errorf("%s: hash %x after placing and removing %v, want %x", pos, s.hash, m, before)
+			}
+		}
+	}
+
+	// boards of different rules hash differently
+	a, _ := Load("X__/___/___")
+	b, _ := Load("2:X__/___/___")
+	if newSearch(a).hash == newSearch(b).hash {
+		t.Error("the same marks hash the same with 2 and 3 in a row")
+	}
+}
+
+func TestTableScores(t *testing.T) {
+	for _, score := range []int{0, 57, -57, win - 3, -(win - 5)} {
+		for _, ply := range []int{0, 1, 6} {
+			if got := fromTable(toTable(score, ply), ply); got != score {
+				t.Errorf("fromTable(toTable(%d, %d)) = %d", score, ply, got)
+			}
+		}
+	}
+	// a win 2 moves from a position found 3 plies deep is stored as a win in 2
+	if got := toTable(win-5, 3); got != win-2 {
+		t.Errorf("toTable(win-5, 3) = win-%d, want win-2", win-got)
+	}
+	if !decided(win-9) || !decided(-(win - 9)) || decided(1<<20) {
+		t.Error("decided() is wrong about wins, losses or heuristic scores")
+	}
+}
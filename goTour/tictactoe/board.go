@@ -0,0 +1,219 @@
+// Package tictactoe is a playable version of the slices lesson's tic-tac-toe board:
+// the same [][]string board of "_", "X" and "O", plus move validation, turns, win and
+// draw detection, undo and a compact text form for positions.
+//
+// Besides the standard game, boards can have any number of rows and columns with any
+// number of marks in a row to win (m,n,k-games such as 15x15 five in a row), and the
+// AI type plays either side.
+package tictactoe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// cell values, as in the slices lesson
+const (
+	Empty = "_"
+	X     = "X" // X moves first
+	O     = "O"
+)
+
+// board size and the number of marks in a row that win in the standard game
+const (
+	Size   = 3
+	InARow = 3
+)
+
+// largest number of rows or columns
+const MaxSize = 20
+
+// errors for invalid moves and positions
+var (
+	ErrOutOfRange    = errors.New("tictactoe: cell is not on the board")
+	ErrOccupied      = errors.New("tictactoe: cell is already taken")
+	ErrGameOver      = errors.New("tictactoe: game is over")
+	ErrNothingToUndo = errors.New("tictactoe: no moves to undo")
+	ErrInvalidBoard  = errors.New("tictactoe: invalid position")
+	ErrInvalidRules  = errors.New("tictactoe: invalid rules")
+)
+
+// board size and win condition: an m,n,k-game has M rows, N columns and is won with K
+// marks in a row, column or diagonal
+type Rules struct {
+	M, N, K int
+}
+
+// Standard is 3x3 tic-tac-toe
+var Standard = Rules{Size, Size, InARow}
+
+// Validate checks the rules describe a playable board
+func (r Rules) Validate() error {
+	switch {
+	case r.M < 1 || r.N < 1 || r.M > MaxSize || r.N > MaxSize:
+		return fmt.Errorf("%w: %dx%d board (rows and columns go from 1 to %d)", ErrInvalidRules, r.M, r.N, MaxSize)
+	case r.K < 1 || (r.K > r.M && r.K > r.N):
+		return fmt.Errorf("%w: %d in a row doesn't fit a %dx%d board", ErrInvalidRules, r.K, r.M, r.N)
+	}
+	return nil
+}
+
+// e.g. "3x3, 3 in a row"
+func (r Rules) String() string {
+	return fmt.Sprintf("%dx%d, %d in a row", r.M, r.N, r.K)
+}
+
+// NewBoard returns an empty board with the rules' size
+func (r Rules) NewBoard() Board {
+	b := make(Board, r.M)
+	for i := range b {
+		b[i] = make([]string, r.N)
+		for j := range b[i] {
+			b[i][j] = Empty
+		}
+	}
+	return b
+}
+
+// tic-tac-toe board: rows of "_", "X" and "O" like the slices lesson's board, so
+// strings.Join(board[i], " ") prints a row
+type Board [][]string
+
+// NewBoard returns an empty standard 3x3 board
+func NewBoard() Board {
+	return Standard.NewBoard()
+}
+
+// String prints the board the way the slices lesson does: one row per line with the
+// cells separated by spaces
+func (b Board) String() string {
+	var sb strings.Builder
+	for i := range b {
+		sb.WriteString(strings.Join(b[i], " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Encode returns the compact form of the board: the rows without spaces, separated by
+// slashes, e.g. "X_X/O_X/__O"
+func (b Board) Encode() string {
+	rows := make([]string, len(b))
+	for i := range b {
+		rows[i] = strings.Join(b[i], "")
+	}
+	return strings.Join(rows, "/")
+}
+
+// ParseBoard reads the compact form written by Encode. Every row must have the same
+// number of cells. Lowercase x and o and "." for an empty cell are accepted too.
+func ParseBoard(s string) (Board, error) {
+	rows := strings.Split(s, "/")
+	if len(rows) > MaxSize || len(rows[0]) == 0 || len(rows[0]) > MaxSize {
+		return nil, fmt.Errorf("%w: %q is not a board of up to %dx%d cells", ErrInvalidBoard, s, MaxSize, MaxSize)
+	}
+	b := make(Board, len(rows))
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("%w: row %q has %d cells, want %d", ErrInvalidBoard, row, len(row), len(rows[0]))
+		}
+		b[i] = make([]string, len(row))
+		for j, c := range row {
+			switch c {
+			case 'X', 'x':
+				b[i][j] = X
+			case 'O', 'o':
+				b[i][j] = O
+			case '_', '.':
+				b[i][j] = Empty
+			default:
+				return nil, fmt.Errorf("%w: unexpected %q in %q", ErrInvalidBoard, c, s)
+			}
+		}
+	}
+	return b, nil
+}
+
+// Clone returns a copy of the board that doesn't share its rows
+func (b Board) Clone() Board {
+	c := make(Board, len(b))
+	for i := range b {
+		c[i] = append([]string(nil), b[i]...)
+	}
+	return c
+}
+
+// Count returns how many cells hold mark
+func (b Board) Count(mark string) int {
+	n := 0
+	for i := range b {
+		for _, c := range b[i] {
+			if c == mark {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// Full reports whether every cell is taken
+func (b Board) Full() bool {
+	return b.Count(Empty) == 0
+}
+
+// Winner returns X or O if that player has k marks in a row, column or diagonal, and
+// "" otherwise
+func (b Board) Winner(k int) string {
+	mark, _ := b.WinningLine(k)
+	return mark
+}
+
+// WinningLine returns the winner and the cells of its line of k marks (nil if nobody
+// has won)
+func (b Board) WinningLine(k int) (string, []Move) {
+	for _, mark := range []string{X, O} {
+		if line := b.lineOf(mark, k); line != nil {
+			return mark, line
+		}
+	}
+	return "", nil
+}
+
+// directions a line can run in: right, down and both diagonals
+var directions = []Move{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+// first line of k marks in a row, column or diagonal (nil if there is none)
+func (b Board) lineOf(mark string, k int) []Move {
+	for r := range b {
+		for c := range b[r] {
+			if b[r][c] != mark {
+				continue
+			}
+			for _, d := range directions {
+				if line := b.line(r, c, d, mark, k); line != nil {
+					return line
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// k cells of mark starting at (r, c) in direction d, or nil
+func (b Board) line(r, c int, d Move, mark string, k int) []Move {
+	line := make([]Move, 0, k)
+	for i := 0; i < k; i++ {
+		m := Move{r + i*d.Row, c + i*d.Col}
+		if !b.onBoard(m) || b[m.Row][m.Col] != mark {
+			return nil
+		}
+		line = append(line, m)
+	}
+	return line
+}
+
+// check if m is a cell of the board
+func (b Board) onBoard(m Move) bool {
+	return m.Row >= 0 && m.Row < len(b) && m.Col >= 0 && m.Col < len(b[m.Row])
+}
@@ -0,0 +1,112 @@
+package tictactoe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		r  Rules
+		ok bool
+	}{
+		{Standard, true},
+		{Rules{15, 15, 5}, true},
+		{Rules{1, 5, 5}, true}, // one row: K fits along it
+		{Rules{MaxSize, MaxSize, 1}, true},
+		{Rules{0, 3, 3}, false},
+		{Rules{3, MaxSize + 1, 3}, false},
+		{Rules{3, 3, 0}, false},
+		{Rules{3, 4, 5}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.r.Validate(); (err == nil) != tt.ok || err != nil && !errors.Is(err, ErrInvalidRules) {
+			t.Errorf("%v: Validate() = %v, want ok %v", tt.r, err, tt.ok)
+		}
+	}
+	if s := (Rules{7, 6, 4}).String(); s != "7x6, 4 in a row" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+func TestBoardEncodeParse(t *testing.T) {
+	tests := []struct {
+		s, encoded string
+		rows       int
+	}{
+		{"___/___/___", "___/___/___", 3},
+		{"X_X/O_X/__O", "X_X/O_X/__O", 3},
+		{"x.o/...", "X_O/___", 2}, // lowercase and dots
+		{"XO", "XO", 1},
+	}
+	for _, tt := range tests {
+		b, err := ParseBoard(tt.s)
+		if err != nil {
+			t.Errorf("ParseBoard(%q): %v", tt.s, err)
+			continue
+		}
+		if len(b) != tt.rows || b.Encode() != tt.encoded {
+			t.Errorf("ParseBoard(%q) = %d rows encoded as %q, want %d rows, %q", tt.s, len(b), b.Encode(), tt.rows, tt.encoded)
+		}
+	}
+
+	for _, s := range []string{"", "X_/___", "XYZ/___/___", "X O/___/___", "/", "_____________________"} {
+		if b, err := ParseBoard(s); !errors.Is(err, ErrInvalidBoard) {
+			t.Errorf("ParseBoard(%q) = %v, %v, want %v", s, b, err, ErrInvalidBoard)
+		}
+	}
+}
+
+func TestBoard(t *testing.T) {
+	b := NewBoard()
+	if b.String() != "_ _ _\n_ _ _\n_ _ _\n" || b.Full() || b.Count(Empty) != 9 {
+		t.Fatalf("NewBoard() = %q", b.String())
+	}
+
+	full, _ := ParseBoard("XOX/XOO/OXX")
+	if !full.Full() || full.Count(X) != 5 || full.Count(O) != 4 || full.Winner(3) != "" {
+		t.Errorf("%s: Full %v, %d X, %d O, winner %q", full.Encode(), full.Full(), full.Count(X), full.Count(O), full.Winner(3))
+	}
+
+	c := full.Clone()
+	c[0][0] = Empty
+	if full[0][0] != X {
+		t.Error("changing a clone changed the board")
+	}
+}
+
+func TestWinningLine(t *testing.T) {
+	tests := []struct {
+		board  string
+		k      int
+		winner string
+		line   []Move
+	}{
+		{"XXX/OO_/___", 3, X, []Move{{0, 0}, {0, 1}, {0, 2}}},
+		{"XX_/OOO/X__", 3, O, []Move{{1, 0}, {1, 1}, {1, 2}}},
+		{"OX_/OX_/O_X", 3, O, []Move{{0, 0}, {1, 0}, {2, 0}}},
+		{"X_O/OX_/__X", 3, X, []Move{{0, 0}, {1, 1}, {2, 2}}},
+		{"X_O/XO_/O_X", 3, O, []Move{{0, 2}, {1, 1}, {2, 0}}},
+		{"XX_/OO_/___", 3, "", nil},
+		{"XX_/OO_/___", 2, X, []Move{{0, 0}, {0, 1}}},
+		// lines of K anywhere on a bigger board, not just from an edge
+		{"_____/_X___/__X__/___X_/_____", 3, X, []Move{{1, 1}, {2, 2}, {3, 3}}},
+		{"_____/_____/_OOOO/_____/_____", 4, O, []Move{{2, 1}, {2, 2}, {2, 3}, {2, 4}}},
+		{"_____/_____/_OOO_/_____/_____", 4, "", nil},
+		{"____X/___X_/__X__/_____/_____", 3, X, []Move{{0, 4}, {1, 3}, {2, 2}}},
+	}
+	for _, tt := range tests {
+		b, err := ParseBoard(tt.board)
+		if err != nil {
+			t.Fatal(err)
+		}
+		winner, line := b.WinningLine(tt.k)
+		if winner != tt.winner || !reflect.DeepEqual(line, tt.line) {
+			t.Errorf("%s with %d in a row: WinningLine() = %q %v, want %q %v", tt.board, tt.k, winner, line, tt.winner, tt.line)
+		}
+		if got := b.Winner(tt.k); got != tt.winner {
+			t.Errorf("%s with %d in a row: Winner() = %q, want %q", tt.board, tt.k, got, tt.winner)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package tictactoe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cell to play in, counting rows and columns from 0
+type Move struct {
+	Row, Col int
+}
+
+// state of a game
+type Status int
+
+const (
+	InProgress Status = iota
+	XWins
+	OWins
+	Draw
+)
+
+// status names as printed by the tictactoe command
+func (s Status) String() string {
+	switch s {
+	case InProgress:
+		return "in progress"
+	case XWins:
+		return "X wins"
+	case OWins:
+		return "O wins"
+	case Draw:
+		return "draw"
+	}
+	return "Status(" + strconv.Itoa(int(s)) + ")"
+}
+
+// Game is a game in progress: the rules, the board, whose turn it is and the moves
+// played so far
+type Game struct {
+	rules Rules
+	board Board
+	moves []Move // moves played since the game started or was loaded
+}
+
+// NewGame returns a standard 3x3 game with X to move
+func NewGame() *Game {
+	return &Game{rules: Standard, board: NewBoard()}
+}
+
+// NewGameWith returns a game on an empty board for the given rules with X to move
+func NewGameWith(r Rules) (*Game, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return &Game{rules: r, board: r.NewBoard()}, nil
+}
+
+// Load returns a game continuing from a position in the compact form of Encode: the
+// board's rows separated by slashes, e.g. "X_O/_X_/O__", with the number of marks in a
+// row that wins in front if it isn't InARow, e.g. "4:X___/_O__/____/____".
+// The player to move follows from the marks on the board (X if both have played as
+// often, O if X has played once more). Moves before the position can't be undone.
+func Load(position string) (*Game, error) {
+	k := InARow
+	if i := strings.IndexByte(position, ':'); i >= 0 {
+		var err error
+		if k, err = strconv.Atoi(position[:i]); err != nil {
+			return nil, fmt.Errorf("%w: %q is not a number of marks in a row", ErrInvalidBoard, position[:i])
+		}
+		position = position[i+1:]
+	}
+	b, err := ParseBoard(position)
+	if err != nil {
+		return nil, err
+	}
+	r := Rules{len(b), len(b[0]), k}
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	xs, os := b.Count(X), b.Count(O)
+	if xs != os && xs != os+1 {
+		return nil, fmt.Errorf("%w: %d X and %d O marks (X moves first and players alternate)", ErrInvalidBoard, xs, os)
+	}
+	xWon, oWon := b.lineOf(X, k) != nil, b.lineOf(O, k) != nil
+	switch {
+	case xWon && oWon:
+		return nil, fmt.Errorf("%w: both players have a line", ErrInvalidBoard)
+	case xWon && xs == os:
+		return nil, fmt.Errorf("%w: O moved after X had won", ErrInvalidBoard)
+	case oWon && xs != os:
+		return nil, fmt.Errorf("%w: X moved after O had won", ErrInvalidBoard)
+	}
+	return &Game{rules: r, board: b}, nil
+}
+
+// Rules returns the board size and win condition
+func (g *Game) Rules() Rules {
+	return g.rules
+}
+
+// Board returns a copy of the current board
+func (g *Game) Board() Board {
+	return g.board.Clone()
+}
+
+// Encode returns the current position in the compact form read by Load
+func (g *Game) Encode() string {
+	if g.rules.K != InARow {
+		return strconv.Itoa(g.rules.K) + ":" + g.board.Encode()
+	}
+	return g.board.Encode()
+}
+
+// String prints the board
+func (g *Game) String() string {
+	return g.board.String()
+}
+
+// Turn returns the mark of the player to move (X or O)
+func (g *Game) Turn() string {
+	if g.board.Count(X) > g.board.Count(O) {
+		return O
+	}
+	return X
+}
+
+// Moves returns the moves played since the game started (or was loaded)
+func (g *Game) Moves() []Move {
+	return append([]Move(nil), g.moves...)
+}
+
+// Winner returns X or O if that player has won, "" otherwise
+func (g *Game) Winner() string {
+	return g.board.Winner(g.rules.K)
+}
+
+// WinningLine returns the winner and the cells of its winning line (nil if nobody has won)
+func (g *Game) WinningLine() (string, []Move) {
+	return g.board.WinningLine(g.rules.K)
+}
+
+// Status reports whether the game is won, drawn or still going
+func (g *Game) Status() Status {
+	switch g.Winner() {
+	case X:
+		return XWins
+	case O:
+		return OWins
+	}
+	if g.board.Full() {
+		return Draw
+	}
+	return InProgress
+}
+
+// Valid checks if the player to move may play m
+func (g *Game) Valid(m Move) error {
+	switch {
+	case g.Status() != InProgress:
+		return ErrGameOver
+	case !g.board.onBoard(m):
+		return fmt.Errorf("%w: row %d, column %d", ErrOutOfRange, m.Row, m.Col)
+	case g.board[m.Row][m.Col] != Empty:
+		return fmt.Errorf("%w: row %d, column %d has %s", ErrOccupied, m.Row, m.Col, g.board[m.Row][m.Col])
+	}
+	return nil
+}
+
+// Play puts the mark of the player to move in cell m
+func (g *Game) Play(m Move) error {
+	if err := g.Valid(m); err != nil {
+		return err
+	}
+	g.board[m.Row][m.Col] = g.Turn()
+	g.moves = append(g.moves, m)
+	return nil
+}
+
+// Undo takes back the last move
+func (g *Game) Undo() error {
+	if len(g.moves) == 0 {
+		return ErrNothingToUndo
+	}
+	m := g.moves[len(g.moves)-1]
+	g.moves = g.moves[:len(g.moves)-1]
+	g.board[m.Row][m.Col] = Empty
+	return nil
+}
+
+// Legal returns every move the player to move can make, row by row
+func (g *Game) Legal() []Move {
+	if g.Status() != InProgress {
+		return nil
+	}
+	var moves []Move
+	for r := range g.board {
+		for c := range g.board[r] {
+			if g.board[r][c] == Empty {
+				moves = append(moves, Move{r, c})
+			}
+		}
+	}
+	return moves
+}
@@ -0,0 +1,191 @@
+package tictactoe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// play the moves, given as row, column pairs
+func play(t *testing.T, g *Game, cells ...int) {
+	t.Helper()
+	for i := 0; i < len(cells); i += 2 {
+		if err := g.Play(Move{cells[i], cells[i+1]}); err != nil {
+			t.Fatalf("Play(%d, %d) on\n%v: %v", cells[i], cells[i+1], g, err)
+		}
+	}
+}
+
+func TestGame(t *testing.T) {
+	tests := []struct {
+		name   string
+		moves  []int
+		status Status
+		turn   string
+	}{
+		{"new", nil, InProgress, X},
+		{"one move", []int{1, 1}, InProgress, O},
+		{"X wins the top row", []int{0, 0, 1, 0, 0, 1, 1, 1, 0, 2}, XWins, O},
+		{"O wins a column", []int{0, 0, 0, 2, 1, 1, 1, 2, 2, 1, 2, 2}, OWins, X},
+		{"X wins with the ninth move", []int{0, 0, 0, 1, 0, 2, 1, 0, 1, 2, 1, 1, 2, 1, 2, 0, 2, 2}, XWins, O},
+		{"draw", []int{0, 0, 0, 1, 0, 2, 1, 1, 1, 0, 1, 2, 2, 1, 2, 0, 2, 2}, Draw, O},
+	}
+	for _, tt := range tests {
+		g := NewGame()
+		play(t, g, tt.moves...)
+		if g.Status() != tt.status || g.Turn() != tt.turn {
+			t.Errorf("%s: status %v with %s to move, want %v with %s to move", tt.name, g.Status(), g.Turn(), tt.status, tt.turn)
+		}
+		if n := len(g.Moves()); n != len(tt.moves)/2 {
+			t.Errorf("%s: %d moves recorded, want %d", tt.name, n, len(tt.moves)/2)
+		}
+		if legal := g.Legal(); (tt.status == InProgress) != (len(legal) > 0) {
+			t.Errorf("%s: %d legal moves with status %v", tt.name, len(legal), tt.status)
+		}
+	}
+	for s, want := range map[Status]string{InProgress: "in progress", XWins: "X wins", OWins: "O wins", Draw: "draw", 7: "Status(7)"} {
+		if s.String() != want {
+			t.Errorf("Status(%d).String() = %q, want %q", int(s), s.String(), want)
+		}
+	}
+}
+
+func TestPlayErrors(t *testing.T) {
+	g := NewGame()
+	play(t, g, 1, 1)
+	tests := []struct {
+		m   Move
+		err error
+	}{
+		{Move{1, 1}, ErrOccupied},
+		{Move{3, 0}, ErrOutOfRange},
+		{Move{0, -1}, ErrOutOfRange},
+	}
+	for _, tt := range tests {
+		if err := g.Play(tt.m); !errors.Is(err, tt.err) {
+			t.Errorf("Play(%v) = %v, want %v", tt.m, err, tt.err)
+		}
+	}
+	if g.Turn() != O || len(g.Moves()) != 1 {
+		t.Errorf("invalid moves changed the game: %s to move after %v", g.Turn(), g.Moves())
+	}
+
+	play(t, g, 0, 0, 0, 1, 2, 2, 2, 1) // X wins the middle column
+	if err := g.Play(Move{2, 0}); !errors.Is(err, ErrGameOver) {
+		t.Errorf("Play after the game is over = %v, want %v", err, ErrGameOver)
+	}
+}
+
+func TestUndo(t *testing.T) {
+	g := NewGame()
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo on a new game = %v, want %v", err, ErrNothingToUndo)
+	}
+	play(t, g, 0, 0, 1, 0, 0, 1, 1, 1, 0, 2)
+	if g.Status() != XWins {
+		t.Fatalf("status %v, want X wins", g.Status())
+	}
+
+	// undoing the winning move reopens the game
+	if err := g.Undo(); err != nil {
+		t.Fatal(err)
+	}
+	if g.Status() != InProgress || g.Turn() != X || g.Encode() != "XX_/OO_/___" {
+		t.Errorf("after Undo: %v, %s to move, position %s", g.Status(), g.Turn(), g.Encode())
+	}
+	for g.Undo() == nil {
+	}
+	if g.Encode() != "___/___/___" || len(g.Moves()) != 0 {
+		t.Errorf("after undoing everything: %s with moves %v", g.Encode(), g.Moves())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		position string
+		rules    Rules
+		turn     string
+		status   Status
+	}{
+		{"___/___/___", Standard, X, InProgress},
+		{"X_O/_X_/O__", Standard, X, InProgress},
+		{"X__/___/___", Standard, O, InProgress},
+		{"XXX/OO_/___", Standard, O, XWins},
+		{"XX_/OOO/X__", Standard, X, OWins},
+		{"XOX/XOO/OXX", Standard, O, Draw},
+		{"4:X___/_O__/____/____", Rules{4, 4, 4}, X, InProgress},
+		{"2:X____/_____", Rules{2, 5, 2}, O, InProgress},
+	}
+	for _, tt := range tests {
+		g, err := Load(tt.position)
+		if err != nil {
+			t.Errorf("Load(%q): %v", tt.position, err)
+			continue
+		}
+		if g.Rules() != tt.rules || g.Turn() != tt.turn || g.Status() != tt.status {
+			t.Errorf("Load(%q) = %v, %s to move, %v; want %v, %s to move, %v", tt.position, g.Rules(), g.Turn(), g.Status(), tt.rules, tt.turn, tt.status)
+		}
+		if got := g.Encode(); got != tt.position {
+			t.Errorf("Load(%q).Encode() = %q", tt.position, got)
+		}
+		// moves before the position can't be undone
+		if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+			t.Errorf("Load(%q).Undo() = %v, want %v", tt.position, err, ErrNothingToUndo)
+		}
+	}
+
+	errs := []struct {
+		position string
+		err      error
+	}{
+		{"", ErrInvalidBoard},
+		{"XX_/___/___", ErrInvalidBoard},   // X moved twice
+		{"O__/___/___", ErrInvalidBoard},   // O moved first
+		{"XXX/OOO/___", ErrInvalidBoard},   // both won
+		{"XXX/OO_/O__", ErrInvalidBoard},   // O moved after X won
+		{"OOO/XX_/X_X", ErrInvalidBoard},   // X moved after O won
+		{"x:___/___/___", ErrInvalidBoard}, // not a number
+		{"4:___/___/___", ErrInvalidRules}, // 4 in a row on 3x3
+		{"0:___/___/___", ErrInvalidRules},
+	}
+	for _, tt := range errs {
+		if g, err := Load(tt.position); !errors.Is(err, tt.err) {
+			t.Errorf("Load(%q) = %v, %v, want %v", tt.position, g, err, tt.err)
+		}
+	}
+}
+
+func TestBoardIsACopy(t *testing.T) {
+	g := NewGame()
+	play(t, g, 1, 1)
+	b := g.Board()
+	b[1][1] = O
+	b[0][0] = X
+	if g.Encode() != "___/_X_/___" {
+		t.Errorf("changing Board() changed the game: %s", g.Encode())
+	}
+	moves := g.Moves()
+	moves[0] = Move{2, 2}
+	if !reflect.DeepEqual(g.Moves(), []Move{{1, 1}}) {
+		t.Errorf("changing Moves() changed the game: %v", g.Moves())
+	}
+}
+
+func TestNewGameWith(t *testing.T) {
+	g, err := NewGameWith(Rules{15, 15, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// five in a row wins on a big board; four doesn't
+	play(t, g, 7, 3, 0, 0, 7, 4, 0, 1, 7, 5, 0, 2, 7, 6)
+	if g.Status() != InProgress {
+		t.Fatalf("status %v after four in a row, want in progress", g.Status())
+	}
+	play(t, g, 0, 3, 7, 7)
+	if _, line := g.WinningLine(); g.Status() != XWins || len(line) != 5 {
+		t.Errorf("status %v with line %v after five in a row, want X wins", g.Status(), line)
+	}
+	if _, err := NewGameWith(Rules{3, 3, 4}); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("NewGameWith(3x3, 4 in a row) = %v, want %v", err, ErrInvalidRules)
+	}
+}
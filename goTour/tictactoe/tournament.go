@@ -0,0 +1,86 @@
+package tictactoe
+
+import "fmt"
+
+// anything that can choose a move, such as an AI
+type Player interface {
+	Move(g *Game) (Move, error)
+}
+
+// wins, draws and losses of one player
+type Record struct {
+	Wins, Draws, Losses int
+}
+
+// Games returns the number of games in the record
+func (r Record) Games() int {
+	return r.Wins + r.Draws + r.Losses
+}
+
+// add the result of a game: winner is the player's mark, the opponent's mark or ""
+func (r *Record) add(winner, mark string) {
+	switch winner {
+	case mark:
+		r.Wins++
+	case "":
+		r.Draws++
+	default:
+		r.Losses++
+	}
+}
+
+// results of a tournament between players A and B, from A's point of view
+type Stats struct {
+	Rules Rules
+	Total Record // all games
+	AsX   Record // games where A moved first
+	AsO   Record // games where B moved first
+	Moves int    // moves played in all games together
+}
+
+// Tournament plays games between a and b with the given rules, alternating which of
+// them moves first (a starts the first game), and returns a's results
+func Tournament(r Rules, a, b Player, games int) (Stats, error) {
+	stats := Stats{Rules: r}
+	for i := 0; i < games; i++ {
+		x, o, mark := a, b, X
+		if i%2 == 1 {
+			x, o, mark = b, a, O
+		}
+		g, err := PlayGame(r, x, o)
+		if err != nil {
+			return stats, fmt.Errorf("game %d: %w", i+1, err)
+		}
+		winner := g.Winner()
+		stats.Total.add(winner, mark)
+		if mark == X {
+			stats.AsX.add(winner, mark)
+		} else {
+			stats.AsO.add(winner, mark)
+		}
+		stats.Moves += len(g.Moves())
+	}
+	return stats, nil
+}
+
+// PlayGame plays one game between x and o and returns the finished game
+func PlayGame(r Rules, x, o Player) (*Game, error) {
+	g, err := NewGameWith(r)
+	if err != nil {
+		return nil, err
+	}
+	for g.Status() == InProgress {
+		p := x
+		if g.Turn() == O {
+			p = o
+		}
+		m, err := p.Move(g)
+		if err != nil {
+			return g, err
+		}
+		if err := g.Play(m); err != nil {
+			return g, err
+		}
+	}
+	return g, nil
+}
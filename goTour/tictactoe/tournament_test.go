@@ -0,0 +1,82 @@
+package tictactoe
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// player taking the first free cell
+type firstFree struct{}
+
+func (firstFree) Move(g *Game) (Move, error) {
+	legal := g.Legal()
+	if len(legal) == 0 {
+		return Move{}, ErrGameOver
+	}
+	return legal[0], nil
+}
+
+// player that always tries the top left cell
+type stubborn struct{}
+
+func (stubborn) Move(*Game) (Move, error) {
+	return Move{0, 0}, nil
+}
+
+func perfect(seed int64) *AI {
+	ai := NewAI(Perfect, rand.NewSource(seed))
+	ai.Budget = 0
+	return ai
+}
+
+func TestTournament(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Player
+		games int
+		want  Stats
+	}{
+		{
+			"perfect players draw",
+			perfect(1), perfect(2), 4,
+			Stats{Rules: Standard, Total: Record{Draws: 4}, AsX: Record{Draws: 2}, AsO: Record{Draws: 2}, Moves: 36},
+		},
+		{
+			// first free against first free: X takes the top row
+			"first free cell",
+			firstFree{}, firstFree{}, 3,
+			Stats{Rules: Standard, Total: Record{Wins: 2, Losses: 1}, AsX: Record{Wins: 2}, AsO: Record{Losses: 1}, Moves: 21},
+		},
+	}
+	for _, tt := range tests {
+		got, err := Tournament(Standard, tt.a, tt.b, tt.games)
+		if err != nil || got != tt.want {
+			t.Errorf("%s: Tournament() = %+v, %v, want %+v", tt.name, got, err, tt.want)
+		}
+		if got.Total.Games() != tt.games {
+			t.Errorf("%s: %d games recorded, want %d", tt.name, got.Total.Games(), tt.games)
+		}
+	}
+
+	// the perfect AI never loses to the first free cell, moving first or second
+	s, err := Tournament(Standard, perfect(1), firstFree{}, 2)
+	if err != nil || s.Total.Losses != 0 || s.Total.Wins != 2 {
+		t.Errorf("perfect against first free cell: %+v, %v", s.Total, err)
+	}
+}
+
+func TestTournamentErrors(t *testing.T) {
+	if _, err := Tournament(Rules{3, 3, 4}, firstFree{}, firstFree{}, 1); !errors.Is(err, ErrInvalidRules) {
+		t.Errorf("Tournament with 4 in a row on 3x3 = %v, want %v", err, ErrInvalidRules)
+	}
+	// the second player's first move is illegal
+	s, err := Tournament(Standard, stubborn{}, stubborn{}, 2)
+	if !errors.Is(err, ErrOccupied) || s.Total.Games() != 0 {
+		t.Errorf("Tournament with an illegal move = %+v, %v, want %v", s, err, ErrOccupied)
+	}
+	g, err := PlayGame(Standard, firstFree{}, stubborn{})
+	if !errors.Is(err, ErrOccupied) || len(g.Moves()) != 1 {
+		t.Errorf("PlayGame with an illegal move = %v after %v, want %v", err, g.Moves(), ErrOccupied)
+	}
+}
@@ -0,0 +1,46 @@
+package tracer
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic turned into an error
+type PanicError struct {
+	Value interface{} // value passed to panic
+	Stack []byte      // stack of the panicking goroutine when it was recovered
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error (e.g. a runtime.Error), so
+// errors.Is and errors.As see through the panic
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// CatchTo stops a panic and sets *errp to a *PanicError for it. It only works as a
+// deferred call, usually in a function with a named error:
+//
+//	defer tracer.CatchTo(&err)
+func CatchTo(errp *error) {
+	if v := recover(); v != nil {
+		*errp = newPanicError(v)
+	}
+}
+
+// Catch calls f and returns a *PanicError if it panics, so a panicking lesson can be
+// reported instead of taking the whole program down
+func Catch(f func()) (err error) {
+	defer CatchTo(&err)
+	f()
+	return nil
+}
+
+// wrap a recovered value, capturing the stack that is still unwinding
+func newPanicError(v interface{}) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
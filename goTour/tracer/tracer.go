@@ -0,0 +1,218 @@
+// Package tracer records what the flow control lesson's deferStatements and stackDefer
+// only print: when deferred calls are registered and run, and which panics happen and
+// get recovered, as an ordered event log with the goroutine and source line of each.
+//
+//	t := tracer.New()
+//	func() {
+//		defer t.Recover("outer")
+//		for i := 0; i < 3; i++ {
+//			defer t.Defer(fmt.Sprint(i), func() {})()
+//		}
+//		t.Panic("boom", "something went wrong")
+//	}()
+//	t.Labels(tracer.Ran) // [2 1 0]: deferred calls run last-in-first-out
+package tracer
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// what happened
+type Kind int
+
+const (
+	Deferred  Kind = iota // a deferred call was registered (the defer statement ran)
+	Ran                   // a deferred call ran
+	Panicked              // Panic was called
+	Recovered             // a deferred Recover or RecoverTo stopped a panic
+)
+
+// kind names as printed in the event log
+func (k Kind) String() string {
+	switch k {
+	case Deferred:
+		return "deferred"
+	case Ran:
+		return "ran"
+	case Panicked:
+		return "panicked"
+	case Recovered:
+		return "recovered"
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// source location of an event
+type Frame struct {
+	Function string // package path qualified function name
+	File     string
+	Line     int
+}
+
+// short form: file name (without its directory) and line
+func (f Frame) String() string {
+	file := f.File
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		file = file[i+1:]
+	}
+	return file + ":" + strconv.Itoa(f.Line)
+}
+
+// entry in the event log
+type Event struct {
+	Seq       int // position in the log, from 1
+	Kind      Kind
+	Label     string
+	Goroutine int64 // ID of the goroutine the event happened on
+	// where it happened: the defer statement for Deferred and Ran, the Panic call for
+	// Panicked and the panicking function for Recovered
+	Frame Frame
+	Value interface{} // panic value for Panicked and Recovered
+}
+
+// one line description, e.g. `#3 g1 ran "print 2" (flow_control.go:154)`
+func (e Event) String() string {
+	s := fmt.Sprintf("#%d g%d %s %q", e.Seq, e.Goroutine, e.Kind, e.Label)
+	if e.Kind == Panicked || e.Kind == Recovered {
+		s += fmt.Sprintf(" value=%v", e.Value)
+	}
+	return s + " (" + e.Frame.String() + ")"
+}
+
+// Tracer is an event log. Safe for use by several goroutines.
+type Tracer struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// New returns an empty tracer
+func New() *Tracer {
+	return &Tracer{}
+}
+
+// Defer records the registration of a deferred call and returns a function that records
+// that it ran and then calls f. Use it as
+//
+//	defer t.Defer("label", f)()
+//
+// so the registration happens when the defer statement runs, like the evaluation of a
+// deferred call's arguments.
+func (t *Tracer) Defer(label string, f func()) func() {
+	at := caller()
+	t.add(Event{Kind: Deferred, Label: label, Frame: at})
+	return func() {
+		t.add(Event{Kind: Ran, Label: label, Frame: at})
+		if f != nil {
+			f()
+		}
+	}
+}
+
+// Panic records a panic and then panics with v
+func (t *Tracer) Panic(label string, v interface{}) {
+	t.add(Event{Kind: Panicked, Label: label, Frame: caller(), Value: v})
+	panic(v)
+}
+
+// Recover stops a panic and records it. It only works as a deferred call:
+//
+//	defer t.Recover("label")
+func (t *Tracer) Recover(label string) {
+	if v := recover(); v != nil {
+		t.add(Event{Kind: Recovered, Label: label, Frame: caller(), Value: v})
+	}
+}
+
+// RecoverTo stops a panic, records it and sets *errp to a *PanicError for it. Like
+// Recover it only works as a deferred call, usually in a function with a named error:
+//
+//	defer t.RecoverTo("label", &err)
+func (t *Tracer) RecoverTo(label string, errp *error) {
+	if v := recover(); v != nil {
+		t.add(Event{Kind: Recovered, Label: label, Frame: caller(), Value: v})
+		*errp = newPanicError(v)
+	}
+}
+
+// Events returns a copy of the event log in order
+func (t *Tracer) Events() []Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	events := make([]Event, len(t.events))
+	copy(events, t.events)
+	return events
+}
+
+// Labels returns the labels of the events of the given kind in order, e.g. the order
+// deferred calls ran in
+func (t *Tracer) Labels(kind Kind) []string {
+	var labels []string
+	for _, e := range t.Events() {
+		if e.Kind == kind {
+			labels = append(labels, e.Label)
+		}
+	}
+	return labels
+}
+
+// Reset clears the event log
+func (t *Tracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = nil
+}
+
+// Print writes the event log to w, one event per line
+func (t *Tracer) Print(w io.Writer) {
+	for _, e := range t.Events() {
+		fmt.Fprintln(w, e)
+	}
+}
+
+// append an event, filling in its sequence number and goroutine
+func (t *Tracer) add(e Event) {
+	e.Goroutine = goroutineID()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e.Seq = len(t.events) + 1
+	t.events = append(t.events, e)
+}
+
+// innermost frame on the stack outside this package and the runtime (the code that
+// called the tracer, or for a recovery the code that panicked)
+func caller() Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !internal(f.Function) {
+			return Frame{Function: f.Function, File: f.File, Line: f.Line}
+		}
+		if !more {
+			return Frame{}
+		}
+	}
+}
+
+// check if a function belongs to this package or the runtime
+func internal(function string) bool {
+	return strings.HasPrefix(function, "runtime.") || strings.HasPrefix(function, "goTour/tracer.")
+}
+
+// ID of the current goroutine, from the "goroutine 18 [running]:" header of its stack
+func goroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	s := strings.TrimPrefix(string(buf), "goroutine ")
+	if i := strings.IndexByte(s, ' '); i >= 0 {
+		s = s[:i]
+	}
+	id, _ := strconv.ParseInt(s, 10, 64)
+	return id
+}
@@ -0,0 +1,124 @@
+package tracer_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"goTour/tracer"
+)
+
+// stackDefer from the flow control lesson, traced
+func stackDefer(t *tracer.Tracer) {
+	for i := 0; i < 5; i++ {
+		defer t.Defer(fmt.Sprint(i), nil)()
+	}
+}
+
+func TestDeferOrder(t *testing.T) {
+	tr := tracer.New()
+	stackDefer(tr)
+
+	if got, want := tr.Labels(tracer.Deferred), []string{"0", "1", "2", "3", "4"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("registered %v, want %v", got, want)
+	}
+	if got, want := tr.Labels(tracer.Ran), []string{"4", "3", "2", "1", "0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ran %v, want %v (last in, first out)", got, want)
+	}
+
+	events := tr.Events()
+	for i, e := range events {
+		if e.Seq != i+1 {
+			t.Errorf("event %d has Seq %d", i, e.Seq)
+		}
+		if !strings.HasSuffix(e.Frame.Function, "tracer_test.stackDefer") {
+			t.Errorf("event %v recorded in %s, want stackDefer", e, e.Frame.Function)
+		}
+	}
+	// every deferred call was registered before any of them ran
+	if events[4].Kind != tracer.Deferred || events[5].Kind != tracer.Ran {
+		t.Errorf("registrations and runs are interleaved:\n%v", events)
+	}
+}
+
+func TestPanicRecover(t *testing.T) {
+	tr := tracer.New()
+	func() {
+		defer tr.Recover("outer")
+		defer tr.Defer("cleanup", nil)()
+		tr.Panic("boom", "something went wrong")
+		t.Error("Panic returned")
+	}()
+
+	var kinds []tracer.Kind
+	for _, e := range tr.Events() {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []tracer.Kind{tracer.Deferred, tracer.Panicked, tracer.Ran, tracer.Recovered}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("events %v, want kinds %v", tr.Events(), want)
+	}
+	if v := tr.Events()[3].Value; v != "something went wrong" {
+		t.Errorf("recovered %v", v)
+	}
+}
+
+func TestRecoverTo(t *testing.T) {
+	tr := tracer.New()
+	err := func() (err error) {
+		defer tr.RecoverTo("index", &err)
+		var s []int
+		_ = s[3]
+		return nil
+	}()
+
+	var re runtime.Error
+	if !errors.As(err, &re) {
+		t.Fatalf("error %v does not wrap a runtime.Error", err)
+	}
+	var pe *tracer.PanicError
+	if !errors.As(err, &pe) || !strings.Contains(string(pe.Stack), "TestRecoverTo") {
+		t.Errorf("error %v has no stack through TestRecoverTo", err)
+	}
+	if got := tr.Labels(tracer.Recovered); !reflect.DeepEqual(got, []string{"index"}) {
+		t.Errorf("recovered %v", got)
+	}
+	// the recovery is attributed to the function that panicked
+	if f := tr.Events()[0].Frame.Function; !strings.Contains(f, "TestRecoverTo") {
+		t.Errorf("recovery recorded in %s", f)
+	}
+}
+
+func TestCatch(t *testing.T) {
+	if err := tracer.Catch(func() {}); err != nil {
+		t.Errorf("Catch without a panic returned %v", err)
+	}
+
+	sentinel := errors.New("sentinel")
+	err := tracer.Catch(func() { panic(sentinel) })
+	if !errors.Is(err, sentinel) {
+		t.Errorf("Catch returned %v, want it to wrap the panic value", err)
+	}
+	if err == nil || err.Error() != "panic: sentinel" {
+		t.Errorf("Catch returned %v", err)
+	}
+}
+
+func TestGoroutines(t *testing.T) {
+	tr := tracer.New()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer tr.Defer("goroutine", nil)()
+	}()
+	<-done
+	defer tr.Defer("test", nil)()
+
+	events := tr.Events()
+	if events[0].Goroutine == 0 || events[0].Goroutine == events[2].Goroutine {
+		t.Errorf("goroutine IDs not recorded: %v", events)
+	}
+}
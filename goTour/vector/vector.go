@@ -0,0 +1,148 @@
+// Package vector is a 2D vector library built on one generic Vertex, replacing the
+// tour's two separate vertices: Vertex{X, Y int} from the structs lesson and
+// Vertex{X, Y float64} with Abs and Scale from the methods lesson.
+//
+// Operations that can leave the integers (Normalize, Lerp, Rotate) return a
+// Vertex[float64]; the rest keep the vertex's own number type. Like Go's own integer
+// arithmetic, integer vertices wrap around on overflow.
+package vector
+
+import "math"
+
+// number types a Vertex can be built from
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// point or vector with (x,y) coordinates
+type Vertex[T Number] struct {
+	X, Y T
+}
+
+// New returns the vertex (x, y)
+func New[T Number](x, y T) Vertex[T] {
+	return Vertex[T]{x, y}
+}
+
+// get Vertex square root of (X^2 + Y^2): its length
+func (v Vertex[T]) Abs() float64 {
+	x, y := float64(v.X), float64(v.Y)
+	return math.Sqrt(x*x + y*y)
+}
+
+// receives pointer and modifies values to multiply with f (integers are truncated, and
+// like any float to integer conversion, out of range results are implementation specific)
+func (v *Vertex[T]) Scale(f float64) {
+	v.X = T(float64(v.X) * f)
+	v.Y = T(float64(v.Y) * f)
+}
+
+// Add returns v + u
+func (v Vertex[T]) Add(u Vertex[T]) Vertex[T] {
+	return Vertex[T]{v.X + u.X, v.Y + u.Y}
+}
+
+// Sub returns v - u
+func (v Vertex[T]) Sub(u Vertex[T]) Vertex[T] {
+	return Vertex[T]{v.X - u.X, v.Y - u.Y}
+}
+
+// Mul returns v with both coordinates multiplied by k (Scale without modifying v)
+func (v Vertex[T]) Mul(k T) Vertex[T] {
+	return Vertex[T]{v.X * k, v.Y * k}
+}
+
+// Neg returns -v
+func (v Vertex[T]) Neg() Vertex[T] {
+	return Vertex[T]{-v.X, -v.Y}
+}
+
+// Dot returns the dot product v·u
+func (v Vertex[T]) Dot(u Vertex[T]) T {
+	return v.X*u.X + v.Y*u.Y
+}
+
+// Cross returns the z coordinate of the 3D cross product of v and u: positive if u is
+// counterclockwise from v, negative if clockwise and 0 if they are parallel
+func (v Vertex[T]) Cross(u Vertex[T]) T {
+	return v.X*u.Y - v.Y*u.X
+}
+
+// Distance returns the length of v - u, computed in float64 so unsigned vertices work
+func (v Vertex[T]) Distance(u Vertex[T]) float64 {
+	dx, dy := float64(v.X)-float64(u.X), float64(v.Y)-float64(u.Y)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// Normalize returns v scaled to length 1, or the zero vertex if v is zero
+func (v Vertex[T]) Normalize() Vertex[float64] {
+	l := v.Abs()
+	if l == 0 {
+		return Vertex[float64]{}
+	}
+	return Vertex[float64]{float64(v.X) / l, float64(v.Y) / l}
+}
+
+// Lerp returns the point a fraction t of the way from v to u (t = 0 gives v, 1 gives u)
+func (v Vertex[T]) Lerp(u Vertex[T], t float64) Vertex[float64] {
+	x, y := float64(v.X), float64(v.Y)
+	return Vertex[float64]{x + (float64(u.X)-x)*t, y + (float64(u.Y)-y)*t}
+}
+
+// Rotate returns v rotated counterclockwise about the origin by theta radians
+func (v Vertex[T]) Rotate(theta float64) Vertex[float64] {
+	sin, cos := math.Sincos(theta)
+	x, y := float64(v.X), float64(v.Y)
+	return Vertex[float64]{x*cos - y*sin, x*sin + y*cos}
+}
+
+// Angle returns the angle of v from the positive x axis in radians, in [-Pi, Pi]
+func (v Vertex[T]) Angle() float64 {
+	return math.Atan2(float64(v.Y), float64(v.X))
+}
+
+// AngleTo returns the angle to rotate v by to point it in u's direction, in [-Pi, Pi]
+// (positive is counterclockwise)
+func (v Vertex[T]) AngleTo(u Vertex[T]) float64 {
+	x1, y1, x2, y2 := float64(v.X), float64(v.Y), float64(u.X), float64(u.Y)
+	return math.Atan2(x1*y2-y1*x2, x1*x2+y1*y2)
+}
+
+// Min returns the component-wise minimum of v and u
+func (v Vertex[T]) Min(u Vertex[T]) Vertex[T] {
+	return Vertex[T]{min(v.X, u.X), min(v.Y, u.Y)}
+}
+
+// Max returns the component-wise maximum of v and u
+func (v Vertex[T]) Max(u Vertex[T]) Vertex[T] {
+	return Vertex[T]{max(v.X, u.X), max(v.Y, u.Y)}
+}
+
+// Float returns v with float64 coordinates
+func (v Vertex[T]) Float() Vertex[float64] {
+	return Convert[float64](v)
+}
+
+// Int returns v with int coordinates, truncated towards zero like int(x)
+func (v Vertex[T]) Int() Vertex[int] {
+	return Convert[int](v)
+}
+
+// Convert returns v with coordinates converted to U using Go's conversion rules
+// (truncation for floats to integers)
+func Convert[U, T Number](v Vertex[T]) Vertex[U] {
+	return Vertex[U]{U(v.X), U(v.Y)}
+}
+
+// Round returns v with its coordinates rounded to the nearest integer (halves away from zero)
+func Round[U Number](v Vertex[float64]) Vertex[U] {
+	return Vertex[U]{U(math.Round(v.X)), U(math.Round(v.Y))}
+}
+
+// ApproxEqual reports whether both coordinates of v and u are within eps of each other,
+// for comparing float vertices after rounding errors (e.g. a Rotate by 2*Pi)
+func (v Vertex[T]) ApproxEqual(u Vertex[T], eps float64) bool {
+	return math.Abs(float64(v.X)-float64(u.X)) <= eps && math.Abs(float64(v.Y)-float64(u.Y)) <= eps
+}
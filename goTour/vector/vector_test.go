@@ -0,0 +1,158 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-12
+
+func TestArithmetic(t *testing.T) {
+	v, u := New(3, 4), New(-1, 2)
+	tests := []struct {
+		name      string
+		got, want Vertex[int]
+	}{
+		{"Add", v.Add(u), Vertex[int]{2, 6}},
+		{"Sub", v.Sub(u), Vertex[int]{4, 2}},
+		{"Mul", v.Mul(-2), Vertex[int]{-6, -8}},
+		{"Neg", u.Neg(), Vertex[int]{1, -2}},
+		{"Min", v.Min(u), Vertex[int]{-1, 2}},
+		{"Max", v.Max(u), Vertex[int]{3, 4}},
+		// integer vertices wrap around like Go's integers
+		{"Add overflow", New(math.MaxInt, 0).Add(New(1, 0)), Vertex[int]{math.MinInt, 0}},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if got := v.Dot(u); got != 5 {
+		t.Errorf("%v.Dot(%v) = %d, want 5", v, u, got)
+	}
+	if got := v.Cross(u); got != 10 {
+		t.Errorf("%v.Cross(%v) = %d, want 10 (u is counterclockwise)", v, u, got)
+	}
+	if got := u.Cross(v); got != -10 {
+		t.Errorf("%v.Cross(%v) = %d, want -10", u, v, got)
+	}
+	if got := v.Cross(v.Mul(3)); got != 0 {
+		t.Errorf("Cross of parallel vertices = %d, want 0", got)
+	}
+}
+
+func TestLengths(t *testing.T) {
+	if got := New(3, 4).Abs(); got != 5 {
+		t.Errorf("Abs() of {3 4} = %v, want 5", got)
+	}
+	if got := New(-3.0, -4.0).Abs(); got != 5 {
+		t.Errorf("Abs() of {-3 -4} = %v, want 5", got)
+	}
+	// unsigned subtraction would wrap around, Distance doesn't
+	if got := New[uint8](1, 1).Distance(New[uint8](4, 5)); got != 5 {
+		t.Errorf("Distance between uint8 {1 1} and {4 5} = %v, want 5", got)
+	}
+	if got := New(0, 0).Normalize(); got != (Vertex[float64]{}) {
+		t.Errorf("Normalize() of the zero vertex = %v, want {0 0}", got)
+	}
+	if got := New(3, 4).Normalize(); !got.ApproxEqual(New(0.6, 0.8), eps) {
+		t.Errorf("Normalize() of {3 4} = %v, want {0.6 0.8}", got)
+	}
+}
+
+func TestScale(t *testing.T) {
+	f := New(3.0, 4.0)
+	f.Scale(10)
+	if f != New(30.0, 40.0) {
+		t.Errorf("float Scale(10) = %v, want {30 40}", f)
+	}
+	// integers are truncated
+	i := New(3, -4)
+	i.Scale(0.5)
+	if i != New(1, -2) {
+		t.Errorf("int Scale(0.5) = %v, want {1 -2}", i)
+	}
+}
+
+func TestGeometry(t *testing.T) {
+	tests := []struct {
+		name      string
+		got, want Vertex[float64]
+	}{
+		{"Lerp 0", New(1, 2).Lerp(New(5, 10), 0), New(1.0, 2.0)},
+		{"Lerp 1", New(1, 2).Lerp(New(5, 10), 1), New(5.0, 10.0)},
+		{"Lerp 1/4", New(1, 2).Lerp(New(5, 10), 0.25), New(2.0, 4.0)},
+		{"Lerp past u", New(0, 0).Lerp(New(1, 1), 2), New(2.0, 2.0)},
+		{"Rotate Pi/2", New(1, 0).Rotate(math.Pi / 2), New(0.0, 1.0)},
+		{"Rotate -Pi/2", New(1, 0).Rotate(-math.Pi / 2), New(0.0, -1.0)},
+		{"Rotate Pi", New(2, 3).Rotate(math.Pi), New(-2.0, -3.0)},
+		{"Rotate 2*Pi", New(2, 3).Rotate(2 * math.Pi), New(2.0, 3.0)},
+	}
+	for _, tt := range tests {
+		if !tt.got.ApproxEqual(tt.want, eps) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	angles := []struct {
+		name      string
+		got, want float64
+	}{
+		{"Angle of {1 0}", New(1, 0).Angle(), 0},
+		{"Angle of {0 1}", New(0, 1).Angle(), math.Pi / 2},
+		{"Angle of {-1 0}", New(-1, 0).Angle(), math.Pi},
+		{"Angle of {1 -1}", New(1, -1).Angle(), -math.Pi / 4},
+		{"AngleTo counterclockwise", New(1, 0).AngleTo(New(0, 1)), math.Pi / 2},
+		{"AngleTo clockwise", New(0, 1).AngleTo(New(1, 0)), -math.Pi / 2},
+		{"AngleTo same direction", New(1, 1).AngleTo(New(3, 3)), 0},
+		{"AngleTo across Pi", New(-1, 1).AngleTo(New(-1, -1)), math.Pi / 2},
+	}
+	for _, tt := range angles {
+		if math.Abs(tt.got-tt.want) > eps {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestConversions(t *testing.T) {
+	f := New(2.7, -2.7)
+	if got := f.Int(); got != New(2, -2) {
+		t.Errorf("Int() of %v = %v, want {2 -2} (truncated)", f, got)
+	}
+	if got := Round[int](f); got != New(3, -3) {
+		t.Errorf("Round of %v = %v, want {3 -3}", f, got)
+	}
+	if got := Round[int](New(0.5, -0.5)); got != New(1, -1) {
+		t.Errorf("Round of {0.5 -0.5} = %v, want halves away from zero", got)
+	}
+	if got := New(3, 4).Float(); got != New(3.0, 4.0) {
+		t.Errorf("Float() of {3 4} = %v", got)
+	}
+	if got := Convert[int16](New[int32](1, -1)); got != New[int16](1, -1) {
+		t.Errorf("Convert[int16] of {1 -1} = %v", got)
+	}
+	// Min and Max keep the number type
+	if got := New[uint](3, 1).Min(New[uint](2, 5)); got != New[uint](2, 1) {
+		t.Errorf("uint Min = %v, want {2 1}", got)
+	}
+}
+
+func TestApproxEqual(t *testing.T) {
+	tests := []struct {
+		v, u Vertex[float64]
+		eps  float64
+		want bool
+	}{
+		{New(1.0, 1.0), New(1.0, 1.0), 0, true},
+		{New(1.0, 1.0), New(1.0+1e-10, 1.0-1e-10), 1e-9, true},
+		{New(1.0, 1.0), New(1.0, 1.1), 1e-9, false},
+		{New(1.0, 1.0), New(1.05, 1.0), 0.1, true},
+		{New(math.NaN(), 0), New(math.NaN(), 0), 1, false},
+	}
+	for _, tt := range tests {
+		if got := tt.v.ApproxEqual(tt.u, tt.eps); got != tt.want {
+			t.Errorf("%v.ApproxEqual(%v, %v) = %v, want %v", tt.v, tt.u, tt.eps, got, tt.want)
+		}
+	}
+}
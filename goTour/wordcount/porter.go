@@ -0,0 +1,219 @@
+package wordcount
+
+// Porter returns the stem of an English word using Martin Porter's 1980 algorithm, so
+// "connect", "connected", "connecting" and "connections" all become "connect". Stems
+// aren't always words ("happy" gives "happi"), they only have to match each other.
+// Words of one or two letters and words with anything but the letters a-z (such as upper
+// case letters or apostrophes) are returned unchanged.
+func Porter(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+	w := []byte(word)
+	for _, step := range []func([]byte) []byte{step1a, step1b, step1c, step2, step3, step4, step5} {
+		w = step(w)
+	}
+	return string(w)
+}
+
+// suffix and its replacement
+type rule struct {
+	suffix, repl string
+}
+
+// plurals: caresses -> caress, ponies -> poni, cats -> cat
+func step1a(w []byte) []byte {
+	w, _ = apply(w, []rule{{"sses", "ss"}, {"ies", "i"}, {"ss", "ss"}, {"s", ""}}, always)
+	return w
+}
+
+// past tenses and -ing: agreed -> agree, plastered -> plaster, motoring -> motor
+func step1b(w []byte) []byte {
+	if hasSuffix(w, "eed") {
+		if measure(w[:len(w)-3]) > 0 {
+			w = w[:len(w)-1]
+		}
+		return w
+	}
+	w, ok := apply(w, []rule{{"ed", ""}, {"ing", ""}}, hasVowel)
+	if !ok {
+		return w
+	}
+	// tidy up the stem: conflat(ed) -> conflate, hopp(ing) -> hop, fil(ing) -> file
+	switch {
+	case hasSuffix(w, "at"), hasSuffix(w, "bl"), hasSuffix(w, "iz"):
+		return append(w, 'e')
+	case doubleConsonant(w) && !hasSuffix(w, "l") && !hasSuffix(w, "s") && !hasSuffix(w, "z"):
+		return w[:len(w)-1]
+	case measure(w) == 1 && cvc(w):
+		return append(w, 'e')
+	}
+	return w
+}
+
+// happy -> happi, but sky stays
+func step1c(w []byte) []byte {
+	w, _ = apply(w, []rule{{"y", "i"}}, hasVowel)
+	return w
+}
+
+// double suffixes to single ones: relational -> relate, digitizer -> digitize
+func step2(w []byte) []byte {
+	w, _ = apply(w, []rule{
+		{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+		{"izer", "ize"}, {"abli", "able"}, {"alli", "al"}, {"entli", "ent"}, {"eli", "e"},
+		{"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"}, {"ator", "ate"},
+		{"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"}, {"ousness", "ous"},
+		{"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	}, measureAbove(0))
+	return w
+}
+
+// -ic-, -ful, -ness etc: triplicate -> triplic, hopeful -> hope, goodness -> good
+func step3(w []byte) []byte {
+	w, _ = apply(w, []rule{
+		{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"}, {"ical", "ic"},
+		{"ful", ""}, {"ness", ""},
+	}, measureAbove(0))
+	return w
+}
+
+// remaining suffixes of longer stems: revival -> reviv, adoption -> adopt
+func step4(w []byte) []byte {
+	long := measureAbove(1)
+	w, _ = apply(w, []rule{
+		{"al", ""}, {"ance", ""}, {"ence", ""}, {"er", ""}, {"ic", ""}, {"able", ""},
+		{"ible", ""}, {"ant", ""}, {"ement", ""}, {"ment", ""}, {"ent", ""}, {"ion", ""},
+		{"ou", ""}, {"ism", ""}, {"ate", ""}, {"iti", ""}, {"ous", ""}, {"ive", ""},
+		{"ize", ""},
+	}, func(stem []byte) bool {
+		if !long(stem) {
+			return false
+		}
+		// -ion only goes after s or t
+		if string(w[len(stem):]) == "ion" {
+			return hasSuffix(stem, "s") || hasSuffix(stem, "t")
+		}
+		return true
+	})
+	return w
+}
+
+// final e and ll: probate -> probat, rate stays, controll -> control
+func step5(w []byte) []byte {
+	if hasSuffix(w, "e") {
+		stem := w[:len(w)-1]
+		if m := measure(stem); m > 1 || (m == 1 && !cvc(stem)) {
+			w = stem
+		}
+	}
+	if measure(w) > 1 && doubleConsonant(w) && hasSuffix(w, "l") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+// replace the longest of the rules' suffixes that w ends with if cond holds for the
+// stem in front of it; reports whether the replacement was made
+func apply(w []byte, rules []rule, cond func(stem []byte) bool) ([]byte, bool) {
+	best := -1
+	for i, r := range rules {
+		if hasSuffix(w, r.suffix) && (best < 0 || len(r.suffix) > len(rules[best].suffix)) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return w, false
+	}
+	stem := w[:len(w)-len(rules[best].suffix)]
+	if !cond(stem) {
+		return w, false
+	}
+	return append(stem, rules[best].repl...), true
+}
+
+// condition that always holds
+func always([]byte) bool {
+	return true
+}
+
+// condition that the stem's measure is above m
+func measureAbove(m int) func([]byte) bool {
+	return func(stem []byte) bool {
+		return measure(stem) > m
+	}
+}
+
+// check if w ends with suffix
+func hasSuffix(w []byte, suffix string) bool {
+	return len(w) >= len(suffix) && string(w[len(w)-len(suffix):]) == suffix
+}
+
+// check if w[i] is a consonant: a letter other than a, e, i, o and u, and y only after
+// a vowel (the y of "toy" is a consonant, the y of "syzygy" isn't)
+func consonant(w []byte, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !consonant(w, i-1)
+	}
+	return true
+}
+
+// measure m of w written as [C](VC){m}[V], where C is a run of consonants and V one of
+// vowels: tree 0, trouble 1, troubles 2
+func measure(w []byte) int {
+	m, i := 0, 0
+	for i < len(w) && consonant(w, i) {
+		i++
+	}
+	for i < len(w) {
+		for i < len(w) && !consonant(w, i) {
+			i++
+		}
+		if i == len(w) {
+			break
+		}
+		for i < len(w) && consonant(w, i) {
+			i++
+		}
+		m++
+	}
+	return m
+}
+
+// check if w has a vowel
+func hasVowel(w []byte) bool {
+	for i := range w {
+		if !consonant(w, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// check if w ends with a double consonant such as -tt or -ss
+func doubleConsonant(w []byte) bool {
+	n := len(w)
+	return n >= 2 && w[n-1] == w[n-2] && consonant(w, n-1)
+}
+
+// check if w ends consonant-vowel-consonant with the last consonant not w, x or y,
+// like hop and fil but not snow or box
+func cvc(w []byte) bool {
+	n := len(w)
+	if n < 3 || !consonant(w, n-3) || consonant(w, n-2) || !consonant(w, n-1) {
+		return false
+	}
+	switch w[n-1] {
+	case 'w', 'x', 'y':
+		return false
+	}
+	return true
+}
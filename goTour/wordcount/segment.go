@@ -0,0 +1,117 @@
+package wordcount
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// call f with every word of s, following the word boundaries of Unicode's text
+// segmentation (UAX #29) in simplified form:
+//   - a word is a run of letters, digits, combining marks and connectors such as "_"
+//   - apostrophes and "." inside a word join its letters ("don't", "U.S.A") and "." and
+//     "," join its digits ("3.14", "1,000"); anywhere else punctuation ends the word
+//   - Chinese characters and hiragana are words of their own
+//
+// Typographic apostrophes are replaced by "'" so "don’t" and "don't" are the same word.
+func segment(s string, f func(word string)) {
+	start := -1 // byte offset of the word being read, -1 between words
+	prev := rune(0)
+	flush := func(end int) {
+		if start >= 0 {
+			f(strings.ReplaceAll(s[start:end], "’", "'"))
+			start = -1
+		}
+	}
+	for i, r := range s {
+		size := utf8.RuneLen(r)
+		switch {
+		case ideograph(r):
+			flush(i)
+			f(s[i : i+size])
+			prev = 0
+			continue
+		case wordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0 && unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+			// marks extend the rune before them
+			continue
+		case start >= 0 && joins(prev, r, s[i+size:]):
+		default:
+			flush(i)
+		}
+		prev = r
+	}
+	flush(len(s))
+}
+
+// check if r can start or continue a word
+func wordRune(r rune) bool {
+	if r < utf8.RuneSelf {
+		return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || r == '_'
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Pc, r)
+}
+
+// Chinese characters and hiragana, which are written without spaces between words
+func ideograph(r rune) bool {
+	return r >= utf8.RuneSelf && unicode.In(r, unicode.Han, unicode.Hiragana)
+}
+
+// check if punctuation r keeps a word going: it must sit between two letters or two
+// digits (prev is the rune before it, rest the text after it)
+func joins(prev, r rune, rest string) bool {
+	next, _ := utf8.DecodeRuneInString(rest)
+	if ideograph(next) {
+		return false
+	}
+	switch {
+	case unicode.IsLetter(prev) && unicode.IsLetter(next):
+		return r == '\'' || r == '’' || r == '.'
+	case unicode.IsDigit(prev) && unicode.IsDigit(next):
+		return r == '.' || r == ','
+	}
+	return false
+}
+
+// fold returns s in lower case with the case differences Unicode's case folding ignores
+// removed, so "Straße", "STRASSE" and "strasse" all give "strasse"
+func fold(s string) string {
+	if lower, ok := foldASCII(s); ok {
+		return lower
+	}
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case 'ß', 'ẞ':
+			sb.WriteString("ss")
+		default:
+			sb.WriteRune(foldRune(r))
+		}
+	}
+	return sb.String()
+}
+
+// fold s if it is ASCII, without copying it if it is already lower case
+func foldASCII(s string) (string, bool) {
+	upper := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			return "", false
+		}
+		upper = upper || ('A' <= c && c <= 'Z')
+	}
+	if !upper {
+		return s, true
+	}
+	return strings.ToLower(s), true
+}
+
+// lower case form of r's upper case, which maps letters with several lower case forms
+// to one: "ſ" to "s", final "ς" to "σ", the micro sign to "μ"
+func foldRune(r rune) rune {
+	return unicode.ToLower(unicode.ToUpper(r))
+}
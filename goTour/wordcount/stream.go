@@ -0,0 +1,180 @@
+package wordcount
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"unicode"
+	"unicode/utf8"
+)
+
+// bytes per chunk used when StreamOptions.ChunkSize is 0
+const DefaultChunkSize = 1 << 20
+
+// smallest chunk size, so a chunk always holds at least a few whole runes
+const minChunkSize = 64
+
+// settings for counting a stream. Memory use is bounded by about 2*Workers+1 chunks plus
+// the words counted.
+type StreamOptions struct {
+	Options
+	ChunkSize int            // bytes read at a time (DefaultChunkSize if 0)
+	Workers   int            // goroutines counting chunks (runtime.GOMAXPROCS(0) if 0)
+	Progress  func(Progress) // called after every chunk is counted, never concurrently
+}
+
+// how much of a stream has been counted
+type Progress struct {
+	Bytes  int64 // input counted so far
+	Chunks int
+	Words  int64
+}
+
+// fill in the default chunk size and number of workers
+func (o StreamOptions) withDefaults() StreamOptions {
+	if o.ChunkSize == 0 {
+		o.ChunkSize = DefaultChunkSize
+	}
+	if o.ChunkSize < minChunkSize {
+		o.ChunkSize = minChunkSize
+	}
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	return o
+}
+
+// CountReader counts the words read from r like Count counts a string, without holding
+// the whole input in memory. The input is cut into chunks at white space, the chunks are
+// counted by a pool of goroutines and their counts merged (map-reduce, like the
+// concurrency lesson's sum goroutines sending on a chan int). A word longer than the
+// chunk size is counted in pieces.
+//
+// If ctx is cancelled CountReader returns ctx.Err() at once; a Read already in progress
+// finishes in the background.
+func CountReader(ctx context.Context, r io.Reader, opts StreamOptions) (map[string]int, error) {
+	opts = opts.withDefaults()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan []byte, opts.Workers)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- split(ctx, r, opts.ChunkSize, !opts.KeepPunctuation, chunks)
+		close(chunks)
+	}()
+
+	counted := make(chan Progress)                      // one per chunk
+	partials := make(chan map[string]int, opts.Workers) // one per worker
+	for i := 0; i < opts.Workers; i++ {
+		go countChunks(ctx, opts.Options, chunks, counted, partials)
+	}
+
+	total := make(map[string]int)
+	var p Progress
+	for working := opts.Workers; working > 0; {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case c := <-counted:
+			p.Bytes += c.Bytes
+			p.Chunks += c.Chunks
+			p.Words += c.Words
+			if opts.Progress != nil {
+				opts.Progress(p)
+			}
+		case m := <-partials:
+			Merge(total, m)
+			working--
+		}
+	}
+	if err := <-errc; err != nil {
+		return nil, err
+	}
+	return total, nil
+}
+
+// Merge adds the counts of src to dst
+func Merge(dst, src map[string]int) {
+	for word, n := range src {
+		dst[word] += n
+	}
+}
+
+// read r in chunks of up to size bytes ending at white space (or after Chinese characters
+// and hiragana if ideographs is set) and send them on chunks
+func split(ctx context.Context, r io.Reader, size int, ideographs bool, chunks chan<- []byte) error {
+	var carry []byte // start of a word cut off at the end of the last chunk
+	for {
+		buf := make([]byte, size)
+		n := copy(buf, carry)
+		m, err := io.ReadFull(r, buf[n:])
+		buf = buf[:n+m]
+
+		end := len(buf)
+		if err == nil { // the buffer is full and more may follow
+			end = cut(buf, ideographs)
+		}
+		carry = append(carry[:0], buf[end:]...)
+		if end > 0 {
+			select {
+			case chunks <- buf[:end]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// where to end a chunk of buf so no word is split: after the last white space (or
+// ideograph), or else after the last whole rune
+func cut(buf []byte, ideographs bool) int {
+	lastRune := 0
+	for end := len(buf); end > 0; {
+		r, size := utf8.DecodeLastRune(buf[:end])
+		if unicode.IsSpace(r) || (ideographs && ideograph(r)) {
+			return end
+		}
+		if lastRune == 0 && !(r == utf8.RuneError && size == 1) {
+			lastRune = end
+		}
+		end -= size
+	}
+	if lastRune == 0 { // not UTF-8 at all
+		return len(buf)
+	}
+	return lastRune
+}
+
+// count the chunks received until chunks is closed, reporting each on counted, then
+// send the counts on partials
+func countChunks(ctx context.Context, opts Options, chunks <-chan []byte, counted chan<- Progress, partials chan<- map[string]int) {
+	m := make(map[string]int)
+	for chunk := range chunks {
+		if ctx.Err() != nil {
+			continue // cancelled: let the reader finish
+		}
+		words := 0
+		opts.each(string(chunk), func(word string) {
+			if _, ok := m[word]; !ok {
+				// copy new words so the map doesn't keep the chunk alive
+				word = string([]byte(word))
+			}
+			m[word]++
+			words++
+		})
+		select {
+		case counted <- Progress{Bytes: int64(len(chunk)), Chunks: 1, Words: int64(words)}:
+		case <-ctx.Done():
+		}
+	}
+	partials <- m
+}
@@ -0,0 +1,120 @@
+package wordcount
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// random text of short words, punctuation, numbers, accents and Chinese characters
+func randomText(n int) string {
+	words := []string{"Go", "go,", "gopher", "naïve", "Straße", "don't", "3.14", "U.S.A.", "我爱", "语言", "e-mail", "(quoted)", "\n"}
+	rnd := rand.New(rand.NewSource(1))
+	var sb strings.Builder
+	for sb.Len() < n {
+		sb.WriteString(words[rnd.Intn(len(words))])
+		sb.WriteString([]string{" ", "  ", "\t", "\n"}[rnd.Intn(4)])
+	}
+	return sb.String()
+}
+
+func TestCountReader(t *testing.T) {
+	text := randomText(50000)
+	for _, opts := range []Options{
+		{},
+		{KeepCase: true, KeepPunctuation: true},
+		{Stem: Porter, StopWords: StopSet(EnglishStopWords...), MinLength: 2},
+	} {
+		want := Count(text, opts)
+		for _, size := range []int{minChunkSize, 100, 4096, DefaultChunkSize} {
+			for _, workers := range []int{1, 4} {
+				t.Run(fmt.Sprintf("%+v/%d/%d", opts, size, workers), func(t *testing.T) {
+					var last Progress
+					got, err := CountReader(context.Background(), strings.NewReader(text), StreamOptions{
+						Options:   opts,
+						ChunkSize: size,
+						Workers:   workers,
+						Progress:  func(p Progress) { last = p },
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if !reflect.DeepEqual(got, want) {
+						t.Errorf("counts differ from Count: got %d words, want %d", len(got), len(want))
+					}
+					if last.Bytes != int64(len(text)) {
+						t.Errorf("progress ended at %d bytes, want %d", last.Bytes, len(text))
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestCut(t *testing.T) {
+	tests := []struct {
+		buf        string
+		ideographs bool
+		want       int
+	}{
+		{"one two thr", true, 8},
+		{"one two\n", true, 8},
+		{"我爱语", true, 9},
+		{"我爱语", false, 9}, // no white space: after the last whole rune
+		{"onetwo我\xe8\xaf", true, 9},
+		{"onetwo\xe8\xaf", true, 6},
+		{"\xff\xfe", true, 2},
+	}
+	for _, tt := range tests {
+		if got := cut([]byte(tt.buf), tt.ideographs); got != tt.want {
+			t.Errorf("cut(%q, %v) = %d, want %d", tt.buf, tt.ideographs, got, tt.want)
+		}
+	}
+}
+
+// reader that cancels a context after some reads
+type cancellingReader struct {
+	r      io.Reader
+	reads  int
+	cancel context.CancelFunc
+}
+
+func (c *cancellingReader) Read(p []byte) (int, error) {
+	if c.reads--; c.reads == 0 {
+		c.cancel()
+	}
+	return c.r.Read(p)
+}
+
+func TestCountReaderCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &cancellingReader{strings.NewReader(randomText(100000)), 3, cancel}
+	_, err := CountReader(ctx, r, StreamOptions{ChunkSize: 1000})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("CountReader returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCountReaderError(t *testing.T) {
+	errBroken := errors.New("broken")
+	r := io.MultiReader(strings.NewReader(randomText(10000)), &failingReader{errBroken})
+	_, err := CountReader(context.Background(), r, StreamOptions{ChunkSize: 1000})
+	if !errors.Is(err, errBroken) {
+		t.Errorf("CountReader returned %v, want %v", err, errBroken)
+	}
+}
+
+// reader that always fails
+type failingReader struct {
+	err error
+}
+
+func (f *failingReader) Read([]byte) (int, error) {
+	return 0, f.err
+}
@@ -0,0 +1,129 @@
+// Package wordcount counts the words of a text, growing the tour's WordCount exercise
+// (moretypes.WordCount, which splits with strings.Fields so "Hello," and "hello" are
+// different words) into a configurable counter: Unicode word segmentation, case folding
+// and punctuation stripping by default, plus optional stemming, stop words and a minimum
+// word length.
+//
+// Counts are plain map[string]int values like the exercise's; Sorted and Top give them
+// as a []WordFreq ordered by frequency, then alphabetically. CountReader counts inputs
+// too large for a string, such as log files, in parallel.
+package wordcount
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// counting settings. The zero value splits text into Unicode words, strips the
+// punctuation around them, folds case and counts every word.
+type Options struct {
+	KeepCase        bool                // count "Go" and "go" as different words
+	KeepPunctuation bool                // split on white space only, like strings.Fields
+	Stem            func(string) string // reduce words to their stem, e.g. Porter (nil to count words as they are)
+	StopWords       map[string]bool     // words not counted, in folded form (see StopSet)
+	MinLength       int                 // shortest word counted, in runes
+}
+
+// word and how often it occurs
+type WordFreq struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// Count returns the number of times each word occurs in s
+func Count(s string, opts Options) map[string]int {
+	m := make(map[string]int)
+	opts.each(s, func(word string) {
+		m[word]++
+	})
+	return m
+}
+
+// Words returns the words of s that Count counts, in the order they appear
+func Words(s string, opts Options) []string {
+	var words []string
+	opts.each(s, func(word string) {
+		words = append(words, word)
+	})
+	return words
+}
+
+// call f with every word of s that is counted, after folding and stemming
+func (o Options) each(s string, f func(word string)) {
+	split := segment
+	if o.KeepPunctuation {
+		split = fields
+	}
+	split(s, func(word string) {
+		folded := fold(word)
+		if o.StopWords[folded] || utf8.RuneCountInString(word) < o.MinLength {
+			return
+		}
+		if !o.KeepCase {
+			word = folded
+		}
+		if o.Stem != nil {
+			word = o.Stem(word)
+		}
+		f(word)
+	})
+}
+
+// call f with every white space separated field of s
+func fields(s string, f func(word string)) {
+	for _, word := range strings.Fields(s) {
+		f(word)
+	}
+}
+
+// Sorted returns the counts from most to least frequent, words with the same count in
+// alphabetical order
+func Sorted(counts map[string]int) []WordFreq {
+	freqs := make([]WordFreq, 0, len(counts))
+	for word, n := range counts {
+		freqs = append(freqs, WordFreq{word, n})
+	}
+	sort.Slice(freqs, func(i, j int) bool {
+		if freqs[i].Count != freqs[j].Count {
+			return freqs[i].Count > freqs[j].Count
+		}
+		return freqs[i].Word < freqs[j].Word
+	})
+	return freqs
+}
+
+// Top returns the n most frequent words in the order of Sorted (all of them if n <= 0)
+func Top(counts map[string]int, n int) []WordFreq {
+	freqs := Sorted(counts)
+	if n > 0 && n < len(freqs) {
+		freqs = freqs[:n]
+	}
+	return freqs
+}
+
+// StopSet returns a stop word set for Options.StopWords, folding the words' case
+func StopSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[fold(w)] = true
+	}
+	return set
+}
+
+// common English words that say little about a text
+var EnglishStopWords = []string{
+	"a", "about", "above", "after", "again", "against", "all", "am", "an", "and", "any",
+	"are", "as", "at", "be", "because", "been", "before", "being", "below", "between",
+	"both", "but", "by", "can", "could", "did", "do", "does", "doing", "don't", "down",
+	"during", "each", "few", "for", "from", "further", "had", "has", "have", "having",
+	"he", "her", "here", "hers", "herself", "him", "himself", "his", "how", "i", "if",
+	"in", "into", "is", "isn't", "it", "it's", "its", "itself", "just", "me", "more",
+	"most", "my", "myself", "no", "nor", "not", "now", "of", "off", "on", "once", "only",
+	"or", "other", "our", "ours", "ourselves", "out", "over", "own", "same", "she",
+	"should", "so", "some", "such", "than", "that", "the", "their", "theirs", "them",
+	"themselves", "then", "there", "these", "they", "this", "those", "through", "to",
+	"too", "under", "until", "up", "very", "was", "we", "were", "what", "when", "where",
+	"which", "while", "who", "whom", "why", "will", "with", "would", "you", "your",
+	"yours", "yourself", "yourselves",
+}
@@ -0,0 +1,86 @@
+package wordcount
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		opts Options
+		want []string
+	}{
+		{"punctuation", `Hello, hello! "Hello?"`, Options{}, []string{"hello", "hello", "hello"}},
+		{"apostrophes", "Don't stop, don’t. The dogs' 'bones'", Options{}, []string{"don't", "stop", "don't", "the", "dogs", "bones"}},
+		{"abbreviations and numbers", "U.S.A. has 1,000 or 3.14 e-mails.", Options{}, []string{"u.s.a", "has", "1,000", "or", "3.14", "e", "mails"}},
+		{"unicode", "Ελληνικά ΣΟΦΙΑ Straße STRASSE naïve", Options{}, []string{"ελληνικά", "σοφια", "strasse", "strasse", "naïve"}},
+		{"combining mark", "naïve", Options{}, []string{"naïve"}},
+		{"ideographs", "我爱Go语言", Options{}, []string{"我", "爱", "go", "语", "言"}},
+		{"snake case", "snake_case stays", Options{}, []string{"snake_case", "stays"}},
+		{"keep case", "Go go GO", Options{KeepCase: true}, []string{"Go", "go", "GO"}},
+		{"keep punctuation", "Hello, hello!", Options{KeepPunctuation: true}, []string{"hello,", "hello!"}},
+		{"stop words", "The cat and THE hat", Options{StopWords: StopSet(EnglishStopWords...)}, []string{"cat", "hat"}},
+		{"min length", "a bb ccc ééé", Options{MinLength: 3}, []string{"ccc", "ééé"}},
+		{"stem", "Connected connections, connecting!", Options{Stem: Porter}, []string{"connect", "connect", "connect"}},
+		{"empty", " \t\n.,!", Options{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Words(tt.in, tt.opts); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// the exercise's behaviour is still available
+func TestCountFields(t *testing.T) {
+	s := "I am learning Go! Go, go."
+	got := Count(s, Options{KeepCase: true, KeepPunctuation: true})
+	want := map[string]int{}
+	for _, f := range strings.Fields(s) {
+		want[f]++
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Count = %v, want %v", got, want)
+	}
+}
+
+func TestTop(t *testing.T) {
+	counts := Count("b a c b a b d", Options{})
+	want := []WordFreq{{"b", 3}, {"a", 2}, {"c", 1}}
+	if got := Top(counts, 3); !reflect.DeepEqual(got, want) {
+		t.Errorf("Top(3) = %v, want %v", got, want)
+	}
+	if got := Top(counts, 0); len(got) != 4 || got[3] != (WordFreq{"d", 1}) {
+		t.Errorf("Top(0) = %v, want all 4 words ending with d", got)
+	}
+}
+
+func TestPorter(t *testing.T) {
+	// examples from Porter's paper
+	tests := map[string]string{
+		"caresses": "caress", "ponies": "poni", "ties": "ti", "caress": "caress", "cats": "cat",
+		"feed": "feed", "agreed": "agre", "plastered": "plaster", "bled": "bled",
+		"motoring": "motor", "sing": "sing", "conflated": "conflat", "troubled": "troubl",
+		"sized": "size", "hopping": "hop", "tanned": "tan", "falling": "fall",
+		"hissing": "hiss", "fizzed": "fizz", "failing": "fail", "filing": "file",
+		"happy": "happi", "sky": "sky", "relational": "relat", "conditional": "condit",
+		"rational": "ration", "valenci": "valenc", "digitizer": "digit", "triplicate": "triplic",
+		"formative": "form", "formalize": "formal", "electrical": "electr", "hopeful": "hope",
+		"goodness": "good", "revival": "reviv", "allowance": "allow", "inference": "infer",
+		"airliner": "airlin", "adjustable": "adjust", "replacement": "replac",
+		"adoption": "adopt", "homologou": "homolog", "communism": "commun",
+		"effective": "effect", "probate": "probat", "rate": "rate", "cease": "ceas",
+		"controll": "control", "roll": "roll", "generalizations": "gener",
+		"oscillators": "oscil", "go": "go", "Running": "Running", "don't": "don't",
+	}
+	for word, want := range tests {
+		if got := Porter(word); got != want {
+			t.Errorf("Porter(%q) = %q, want %q", word, got, want)
+		}
+	}
+}